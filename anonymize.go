@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+const redactedNotes = "[redacted]"
+
+// anonymizer swaps names for pseudonyms such as Scholar-1a2b. A pseudonym is a
+// salted hash of the name, so the same scholar reads the same in every output
+// of a run, and it grows past four hex digits only when two names collide.
+type anonymizer struct {
+	salt   string
+	groups bool
+	names  map[string]string
+	taken  map[string]string
+}
+
+func newAnonymizer(salt string, groups bool) *anonymizer {
+	return &anonymizer{
+		salt:   salt,
+		groups: groups,
+		names:  make(map[string]string),
+		taken:  make(map[string]string),
+	}
+}
+
+// newRunAnonymizer salts with PACECONSOLE_ANONYMIZE_SALT when set, which keeps
+// pseudonyms stable across runs; otherwise the salt is random per run.
+// requireSalt refuses the random fallback, for synced snapshots that must be
+// compared across runs.
+func newRunAnonymizer(groups, requireSalt bool) (*anonymizer, error) {
+	salt := os.Getenv("PACECONSOLE_ANONYMIZE_SALT")
+	if salt == "" {
+		if requireSalt {
+			return nil, errors.New("PACECONSOLE_ANONYMIZE_SALT must be set with -db-sync-anonymize so pseudonyms match across synced snapshots")
+		}
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		salt = hex.EncodeToString(buf)
+	}
+	return newAnonymizer(salt, groups), nil
+}
+
+func (a *anonymizer) pseudonym(kind, name string) string {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return name
+	}
+	key := kind + "\x00" + normalized
+	if alias, ok := a.names[key]; ok {
+		return alias
+	}
+	sum := sha256.Sum256([]byte(a.salt + "\x00" + key))
+	digest := hex.EncodeToString(sum[:])
+	alias := ""
+	for size := 4; size <= len(digest); size += 2 {
+		alias = kind + "-" + digest[:size]
+		if owner, ok := a.taken[alias]; !ok || owner == key {
+			break
+		}
+	}
+	a.names[key] = alias
+	a.taken[alias] = key
+	return alias
+}
+
+func (a *anonymizer) record(record Disbursement) Disbursement {
+	record.Scholar = a.pseudonym("Scholar", record.Scholar)
+	if strings.TrimSpace(record.Notes) != "" {
+		record.Notes = redactedNotes
+	}
+	if a.groups {
+		record.Owner = a.pseudonym("Owner", record.Owner)
+		record.Cohort = a.pseudonym("Cohort", record.Cohort)
+	}
+	return record
+}
+
+// items returns anonymized copies; pacing, check-ins, and risk are kept as
+// computed so aggregates match the named data.
+func (a *anonymizer) items(items []awardItem) []awardItem {
+	anonymized := make([]awardItem, len(items))
+	for i, item := range items {
+		anonymized[i] = newAwardItem(pacing.Item{
+			Index:    item.index,
+			Record:   a.record(item.data),
+			Pace:     item.pace,
+			Checkin:  item.check,
+			Risk:     item.risk,
+			Forecast: item.forecast,
+		})
+		anonymized[i].dateFormat = item.dateFormat
+	}
+	return anonymized
+}
+
+// scholarRows pseudonymizes snapshot rows the same way as records. Notes are
+// left for the caller to compare; see redactNoteChanges.
+func (a *anonymizer) scholarRows(rows []scholarSnapshotRow) []scholarSnapshotRow {
+	anonymized := make([]scholarSnapshotRow, len(rows))
+	for i, row := range rows {
+		row.Scholar = a.pseudonym("Scholar", row.Scholar)
+		if a.groups {
+			row.Owner = a.pseudonym("Owner", row.Owner)
+			row.Cohort = a.pseudonym("Cohort", row.Cohort)
+		}
+		anonymized[i] = row
+	}
+	return anonymized
+}
+
+func redactNoteChanges(changes []scholarNoteChange) []scholarNoteChange {
+	redacted := make([]scholarNoteChange, len(changes))
+	for i, change := range changes {
+		if change.PreviousNotes != "" {
+			change.PreviousNotes = redactedNotes
+		}
+		if change.CurrentNotes != "" {
+			change.CurrentNotes = redactedNotes
+		}
+		redacted[i] = change
+	}
+	return redacted
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+// attentionItem is one row of the attention queue: an award with a risk flag,
+// its place in priority order, and the flag that weighs most as the reason.
+type attentionItem struct {
+	Rank   int    `json:"rank"`
+	Reason string `json:"reason"`
+	exportItem
+}
+
+func buildAttentionQueue(items []awardItem, config pacing.RiskConfig) []attentionItem {
+	queue := make([]attentionItem, 0)
+	for _, item := range sortItems(items, "priority") {
+		if len(item.risk.Flags) == 0 {
+			continue
+		}
+		queue = append(queue, attentionItem{
+			Rank:       len(queue) + 1,
+			Reason:     topRiskFlag(item, config),
+			exportItem: buildExportItem(item),
+		})
+	}
+	return queue
+}
+
+// topRiskFlag picks the flag with the most points; ties keep the flag order
+// from CalculateRisk, so Behind and Overdue lead.
+func topRiskFlag(item awardItem, config pacing.RiskConfig) string {
+	flags := pacing.RiskBreakdown(item.risk, item.pace, config)[:len(item.risk.Flags)]
+	top := flags[0]
+	for _, contribution := range flags[1:] {
+		if contribution.Points > top.Points {
+			top = contribution
+		}
+	}
+	return top.Reason
+}
+
+func exportAttentionJSON(path string, items []awardItem, config pacing.RiskConfig) error {
+	content, err := json.MarshalIndent(buildAttentionQueue(items, config), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type bundlePaths struct {
+	Export string
+	Report string
+	ICS    string
+}
+
+// resolveBundlePaths lays out -output-dir. Unset flags get dated default
+// names, bare file names are placed in dir, and paths with a directory (or
+// stdout) are used exactly as given.
+func resolveBundlePaths(dir, exportPath, reportPath, icsPath, reportFormat string, generatedAt time.Time) (bundlePaths, error) {
+	format, err := normalizeReportFormat(reportPath, reportFormat)
+	if err != nil {
+		return bundlePaths{}, err
+	}
+	stamp := generatedAt.Format("2006-01-02")
+	return bundlePaths{
+		Export: bundlePath(dir, exportPath, fmt.Sprintf("pacing-snapshot-%s.csv", stamp)),
+		Report: bundlePath(dir, reportPath, fmt.Sprintf("pacing-report-%s%s", stamp, reportFileExtension(format))),
+		ICS:    bundlePath(dir, icsPath, fmt.Sprintf("pacing-checkins-%s.ics", stamp)),
+	}, nil
+}
+
+func bundlePath(dir, explicit, fallback string) string {
+	explicit = strings.TrimSpace(explicit)
+	switch {
+	case explicit == "":
+		return filepath.Join(dir, fallback)
+	case isStdoutTarget(explicit) || filepath.IsAbs(explicit) || filepath.Base(explicit) != explicit:
+		return explicit
+	}
+	return filepath.Join(dir, explicit)
+}
+
+func reportFileExtension(format string) string {
+	switch format {
+	case "json":
+		return ".json"
+	case "markdown":
+		return ".md"
+	}
+	return ".txt"
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+// clock is the single source of "now" for pacing, check-ins, and timestamps.
+// -now swaps in a fixedClock; elapsed-time logging still reads the wall clock.
+type clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+// parseClock reads -now: empty is the system clock, a bare date is midnight in
+// location (or local time), and anything else must be RFC3339.
+func parseClock(value string, location *time.Location) (clock, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return systemClock{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return fixedClock(parsed), nil
+	}
+	if location == nil {
+		location = time.Local
+	}
+	day, err := time.ParseInLocation(pacing.ISODateLayout, value, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -now %q (use RFC3339 or YYYY-MM-DD)", value)
+	}
+	return fixedClock(day), nil
+}
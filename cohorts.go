@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+func buildCohortTable(items []awardItem, width int) string {
+	if len(items) == 0 {
+		return "No records loaded."
+	}
+	summaries := buildCohortSummaries(items)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Completion != summaries[j].Completion {
+			return summaries[i].Completion < summaries[j].Completion
+		}
+		return strings.ToLower(summaries[i].Cohort) < strings.ToLower(summaries[j].Cohort)
+	})
+
+	nameWidth := 6
+	for _, summary := range summaries {
+		if w := lipgloss.Width(summary.Cohort); w > nameWidth {
+			nameWidth = w
+		}
+	}
+	const statsWidth = 36
+	if width > 0 && nameWidth > width-statsWidth {
+		nameWidth = max(width-statsWidth, 6)
+	}
+
+	lines := []string{
+		"Cohorts by completion:",
+		fmt.Sprintf("%-*s %6s %7s %12s %7s", nameWidth, "Cohort", "Awards", "Done", "Gap", "Behind"),
+	}
+	for _, summary := range summaries {
+		lines = append(lines, fmt.Sprintf("%-*s %6d %6.1f%% %12s %7d",
+			nameWidth,
+			truncateCell(summary.Cohort, nameWidth),
+			summary.Awards,
+			summary.Completion*100,
+			formatSignedCurrency(summary.GapTotal),
+			summary.Behind,
+		))
+	}
+	table := strings.Join(lines, "\n")
+	if width > 0 {
+		table = lipgloss.NewStyle().MaxWidth(width).Render(table)
+	}
+	return table
+}
+
+func truncateCell(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+type cohortMeta struct {
+	Deadline          string `json:"deadline"`
+	CheckinWindowDays *int   `json:"checkin_window_days"`
+}
+
+// cohortConfig is a parsed -cohort-config file. Deadlines and CheckinWindows
+// are keyed by cohortKey; Windows keeps the configured names for reports.
+type cohortConfig struct {
+	Deadlines      map[string]time.Time
+	CheckinWindows map[string]int
+	Windows        []cohortWindow
+}
+
+type cohortWindow struct {
+	Cohort string `json:"cohort"`
+	Days   int    `json:"checkin_window_days"`
+}
+
+type cohortDeadline struct {
+	Cohort           string  `json:"cohort"`
+	Currency         string  `json:"currency"`
+	Deadline         string  `json:"deadline"`
+	DaysToDeadline   int     `json:"days_to_deadline"`
+	DollarCompletion float64 `json:"dollar_completion"`
+	Projected        string  `json:"projected_completion,omitempty"`
+	Late             bool    `json:"late"`
+	// split marks a cohort whose awards span currencies, so its lines name
+	// the currency to tell the per-currency rows apart.
+	split bool
+}
+
+// loadCohortConfig reads a JSON object of cohort name to metadata, e.g.
+// {"Spring 2025": {"deadline": "2026-06-30", "checkin_window_days": 7}},
+// keyed case-insensitively. Both fields are optional per cohort, and names
+// that differ only in case or surrounding spaces are rejected.
+func loadCohortConfig(path string, dates pacingConfig) (cohortConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cohortConfig{}, err
+	}
+	var meta map[string]cohortMeta
+	if err := json.Unmarshal(content, &meta); err != nil {
+		return cohortConfig{}, err
+	}
+	config := cohortConfig{
+		Deadlines:      make(map[string]time.Time, len(meta)),
+		CheckinWindows: make(map[string]int),
+	}
+	names := make([]string, 0, len(meta))
+	for cohort := range meta {
+		names = append(names, cohort)
+	}
+	sort.Strings(names)
+	seen := make(map[string]string, len(names))
+	for _, cohort := range names {
+		if previous, ok := seen[cohortKey(cohort)]; ok {
+			return cohortConfig{}, fmt.Errorf("cohort %q: duplicates %q (cohort names are case-insensitive)", cohort, previous)
+		}
+		seen[cohortKey(cohort)] = cohort
+		entry := meta[cohort]
+		if strings.TrimSpace(entry.Deadline) != "" {
+			date, ok := dates.ParseDate(entry.Deadline)
+			if !ok {
+				return cohortConfig{}, fmt.Errorf("cohort %q: unparseable deadline %q", cohort, entry.Deadline)
+			}
+			config.Deadlines[cohortKey(cohort)] = date
+		}
+		if entry.CheckinWindowDays != nil {
+			if *entry.CheckinWindowDays < 0 {
+				return cohortConfig{}, fmt.Errorf("cohort %q: negative checkin_window_days %d", cohort, *entry.CheckinWindowDays)
+			}
+			config.CheckinWindows[cohortKey(cohort)] = *entry.CheckinWindowDays
+			config.Windows = append(config.Windows, cohortWindow{Cohort: strings.TrimSpace(cohort), Days: *entry.CheckinWindowDays})
+		}
+	}
+	sort.Slice(config.Windows, func(i, j int) bool {
+		return strings.ToLower(config.Windows[i].Cohort) < strings.ToLower(config.Windows[j].Cohort)
+	})
+	return config, nil
+}
+
+func formatCohortWindows(windows []cohortWindow) string {
+	parts := make([]string, 0, len(windows))
+	for _, window := range windows {
+		parts = append(parts, fmt.Sprintf("%s %d days", window.Cohort, window.Days))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func cohortKey(cohort string) string {
+	return strings.ToLower(strings.TrimSpace(cohort))
+}
+
+// buildCohortDeadlines projects each configured cohort's completion date from
+// its aggregate disbursement rate since the earliest award date. Cohorts with
+// money left and nothing disbursed cannot be projected and count as late.
+// Totals are kept per currency, so a cohort mixing currencies gets one row
+// per currency rather than a sum across them.
+func buildCohortDeadlines(items []awardItem, deadlines map[string]time.Time, now time.Time) []cohortDeadline {
+	if len(deadlines) == 0 {
+		return nil
+	}
+	type cohortTotals struct {
+		name      string
+		cohort    string
+		currency  string
+		awarded   float64
+		disbursed float64
+		start     time.Time
+	}
+	index := make(map[string]*cohortTotals)
+	order := make([]string, 0)
+	names := make(map[string]string)
+	currencies := make(map[string]int)
+	for _, item := range items {
+		cohort := cohortKey(item.data.Cohort)
+		if _, ok := deadlines[cohort]; !ok {
+			continue
+		}
+		if _, ok := names[cohort]; !ok {
+			names[cohort] = item.data.Cohort
+		}
+		if !hasAmount(item.data) {
+			continue
+		}
+		code := normalizeCurrency(item.data.Currency)
+		key := cohort + "\x00" + code
+		entry, ok := index[key]
+		if !ok {
+			entry = &cohortTotals{name: names[cohort], cohort: cohort, currency: code}
+			index[key] = entry
+			order = append(order, key)
+			currencies[cohort]++
+		}
+		entry.awarded += item.data.Amount
+		entry.disbursed += item.data.DisbursedToDate
+		if awardDate, ok := item.dateConfig().ParseDate(item.data.AwardDate); ok && (entry.start.IsZero() || awardDate.Before(entry.start)) {
+			entry.start = awardDate
+		}
+	}
+
+	for cohort, name := range names {
+		if currencies[cohort] == 0 {
+			key := cohort + "\x00"
+			index[key] = &cohortTotals{name: name, cohort: cohort, currency: normalizeCurrency("")}
+			order = append(order, key)
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	results := make([]cohortDeadline, 0, len(order))
+	for _, key := range order {
+		entry := index[key]
+		deadline := deadlines[entry.cohort]
+		deadlineDay := time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, today.Location())
+		result := cohortDeadline{
+			Cohort:         entry.name,
+			Currency:       entry.currency,
+			split:          currencies[entry.cohort] > 1,
+			Deadline:       deadlineDay.Format(pacing.ISODateLayout),
+			DaysToDeadline: int(math.Round(deadlineDay.Sub(today).Hours() / 24)),
+		}
+		if entry.awarded > 0 {
+			result.DollarCompletion = entry.disbursed / entry.awarded
+		}
+		remaining := entry.awarded - entry.disbursed
+		switch {
+		case remaining <= 0:
+			result.Projected = today.Format(pacing.ISODateLayout)
+		case entry.disbursed > 0 && !entry.start.IsZero():
+			startDay := time.Date(entry.start.Year(), entry.start.Month(), entry.start.Day(), 0, 0, 0, 0, today.Location())
+			elapsedDays := math.Max(1, today.Sub(startDay).Hours()/24)
+			daysLeft := math.Ceil(remaining / (entry.disbursed / elapsedDays))
+			projected := today.AddDate(0, 0, int(daysLeft))
+			result.Projected = projected.Format(pacing.ISODateLayout)
+			result.Late = projected.After(deadlineDay)
+		default:
+			result.Late = true
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Late != results[j].Late {
+			return results[i].Late
+		}
+		if results[i].DaysToDeadline != results[j].DaysToDeadline {
+			return results[i].DaysToDeadline < results[j].DaysToDeadline
+		}
+		if !strings.EqualFold(results[i].Cohort, results[j].Cohort) {
+			return strings.ToLower(results[i].Cohort) < strings.ToLower(results[j].Cohort)
+		}
+		return results[i].Currency < results[j].Currency
+	})
+	return results
+}
+
+// label is the cohort name, plus the currency when the cohort is split.
+func (d cohortDeadline) label() string {
+	if d.split {
+		return fmt.Sprintf("%s (%s)", d.Cohort, d.Currency)
+	}
+	return d.Cohort
+}
+
+func formatCohortDeadlineLine(deadline cohortDeadline) string {
+	projection := "no disbursements to project from"
+	if deadline.Projected != "" {
+		projection = "projected " + deadline.Projected
+	}
+	line := fmt.Sprintf("- %s · deadline %s (%s) · %0.1f%% of dollars disbursed · %s",
+		deadline.label(),
+		deadline.Deadline,
+		formatDaysLabel(deadline.DaysToDeadline),
+		deadline.DollarCompletion*100,
+		projection,
+	)
+	if deadline.Late {
+		line += " · LATE"
+	}
+	return line
+}
+
+func buildCohortDeadlinePanel(deadlines []cohortDeadline, width int) string {
+	if len(deadlines) == 0 {
+		return ""
+	}
+	lines := []string{"Cohort deadlines:"}
+	for _, deadline := range deadlines {
+		lines = append(lines, formatCohortDeadlineLine(deadline))
+	}
+	panel := strings.Join(lines, "\n")
+	if width > 0 {
+		panel = lipgloss.NewStyle().MaxWidth(width).Render(panel)
+	}
+	return panel
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	compactMaxScholarWidth = 28
+	compactPaceWidth       = 12
+	compactGapWidth        = 10
+)
+
+type compactDelegate struct {
+	scholarWidth int
+}
+
+func newCompactDelegate(items []awardItem) compactDelegate {
+	width := len("Scholar")
+	for _, item := range items {
+		width = max(width, lipgloss.Width(item.data.Scholar))
+	}
+	return compactDelegate{scholarWidth: min(width, compactMaxScholarWidth)}
+}
+
+func (d compactDelegate) Height() int                             { return 1 }
+func (d compactDelegate) Spacing() int                            { return 0 }
+func (d compactDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d compactDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(awardItem)
+	if !ok {
+		return
+	}
+	cursor := "  "
+	scholar := lipgloss.NewStyle().Width(d.scholarWidth).Render(truncateCell(item.data.Scholar, d.scholarWidth))
+	if index == m.Index() {
+		cursor = accent.Render("> ")
+		scholar = accent.Render(scholar)
+	}
+	line := compactRow(cursor, scholar,
+		renderPaceLabel(item.pace),
+		formatSignedCurrencyIn(item.pace.GapAmount, item.data.Currency),
+		fmt.Sprintf("%s (%s)", renderCheckinLabel(item.check), compactDaysLabel(item.check)),
+	)
+	if width := m.Width(); width > 0 {
+		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+	fmt.Fprint(w, line)
+}
+
+func compactRow(cursor, scholar, pace, gap, checkin string) string {
+	return strings.Join([]string{
+		cursor + scholar,
+		lipgloss.NewStyle().Width(compactPaceWidth).Render(pace),
+		lipgloss.NewStyle().Width(compactGapWidth).Align(lipgloss.Right).Render(gap),
+		checkin,
+	}, "  ")
+}
+
+func compactDaysLabel(check checkinStatus) string {
+	if check.Label == "Unscheduled" {
+		return "—"
+	}
+	return formatDaysLabel(check.Days)
+}
+
+func (m *model) applyListDelegate() {
+	if m.compact {
+		m.list.SetDelegate(newCompactDelegate(m.baseItems))
+		return
+	}
+	m.list.SetDelegate(list.NewDefaultDelegate())
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func applyConfigFile(fs *flag.FlagSet, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var values map[string]any
+	if err := json.Unmarshal(content, &values); err != nil {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	explicit := make(map[string]struct{})
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = struct{}{}
+	})
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "config" {
+			continue
+		}
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("config %s: unknown flag %q", path, name)
+		}
+		if _, ok := explicit[name]; ok {
+			continue
+		}
+		value, err := configValueString(values[name])
+		if err != nil {
+			return fmt.Errorf("config %s: %s: %w", path, name, err)
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("config %s: %s: %w", path, name, err)
+		}
+	}
+	return nil
+}
+
+func configValueString(value any) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case []any:
+		parts := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			part, err := configValueString(entry)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ","), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const defaultCurrency = "USD"
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+type currencyTotal struct {
+	Currency  string  `json:"currency"`
+	Count     int     `json:"count"`
+	Awarded   float64 `json:"total_awarded"`
+	Disbursed float64 `json:"total_disbursed"`
+	Expected  float64 `json:"total_expected"`
+	Gap       float64 `json:"total_gap"`
+	Remaining float64 `json:"total_remaining"`
+}
+
+func normalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return defaultCurrency
+	}
+	return code
+}
+
+func currencySymbol(code string) string {
+	code = normalizeCurrency(code)
+	if symbol, ok := currencySymbols[code]; ok {
+		return symbol
+	}
+	return code + " "
+}
+
+func formatCurrency(value float64, code string) string {
+	return activeLocale.money(activeLocale.wholeNumber(value), currencySymbol(code))
+}
+
+// formatCurrencyCents keeps cents for report totals, where formatCurrency
+// rounds to whole units.
+func formatCurrencyCents(value float64, code string) string {
+	return activeLocale.money(activeLocale.decimal(value), currencySymbol(code))
+}
+
+func formatSignedCurrencyIn(value float64, code string) string {
+	sign := "-"
+	if value >= 0 {
+		sign = "+"
+	}
+	return sign + formatCurrency(math.Abs(value), code)
+}
+
+func buildCurrencyTotals(items []awardItem) []currencyTotal {
+	index := make(map[string]*currencyTotal)
+	for _, item := range items {
+		code := normalizeCurrency(item.data.Currency)
+		entry, ok := index[code]
+		if !ok {
+			entry = &currencyTotal{Currency: code}
+			index[code] = entry
+		}
+		entry.Count++
+		if !hasAmount(item.data) {
+			continue
+		}
+		entry.Awarded += item.data.Amount
+		entry.Disbursed += item.data.DisbursedToDate
+		entry.Expected += item.pace.ExpectedAmount
+		entry.Gap += item.pace.GapAmount
+		entry.Remaining += math.Max(0, item.data.Amount-item.data.DisbursedToDate)
+	}
+	totals := make([]currencyTotal, 0, len(index))
+	for _, entry := range index {
+		totals = append(totals, *entry)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Currency < totals[j].Currency
+	})
+	return totals
+}
+
+func formatCurrencyTotals(totals []currencyTotal) string {
+	parts := make([]string, 0, len(totals))
+	for _, total := range totals {
+		parts = append(parts, fmt.Sprintf("%s %s awarded / %s disbursed (gap %s)",
+			total.Currency,
+			formatCurrency(total.Awarded, total.Currency),
+			formatCurrency(total.Disbursed, total.Currency),
+			formatSignedCurrencyIn(total.Gap, total.Currency),
+		))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func summaryTotalRows(metrics summaryMetrics) [][2]string {
+	if len(metrics.Currencies) <= 1 {
+		return [][2]string{
+			{"Total awarded", activeLocale.decimal(metrics.TotalAwarded)},
+			{"Total disbursed", activeLocale.decimal(metrics.TotalDisbursed)},
+			{"Total expected", activeLocale.decimal(metrics.TotalExpected)},
+			{"Total gap", activeLocale.decimal(metrics.TotalGap)},
+			{"Total remaining", activeLocale.decimal(metrics.TotalRemaining)},
+			{"Completion", fmt.Sprintf("%0.1f%%", metrics.Completion*100)},
+		}
+	}
+	rows := [][2]string{{"Currencies", "Mixed; totals are grouped per currency and not combined"}}
+	for _, total := range metrics.Currencies {
+		completion := 0.0
+		if total.Awarded > 0 {
+			completion = total.Disbursed / total.Awarded
+		}
+		rows = append(rows,
+			[2]string{fmt.Sprintf("Total awarded (%s)", total.Currency), activeLocale.decimal(total.Awarded)},
+			[2]string{fmt.Sprintf("Total disbursed (%s)", total.Currency), activeLocale.decimal(total.Disbursed)},
+			[2]string{fmt.Sprintf("Total expected (%s)", total.Currency), activeLocale.decimal(total.Expected)},
+			[2]string{fmt.Sprintf("Total gap (%s)", total.Currency), activeLocale.decimal(total.Gap)},
+			[2]string{fmt.Sprintf("Total remaining (%s)", total.Currency), activeLocale.decimal(total.Remaining)},
+			[2]string{fmt.Sprintf("Completion (%s)", total.Currency), fmt.Sprintf("%0.1f%%", completion*100)},
+		)
+	}
+	return rows
+}
+
+func (m summaryMetrics) currency() string {
+	if len(m.Currencies) == 1 {
+		return m.Currencies[0].Currency
+	}
+	return defaultCurrency
+}
+
+// combinedTotal is a cross-award total for JSON exports, or nil when the
+// awards mix currencies and adding them up would be meaningless.
+func combinedTotal(metrics summaryMetrics, value float64) *float64 {
+	if len(metrics.Currencies) > 1 {
+		return nil
+	}
+	return &value
+}
+
+// combinedTotalCell is combinedTotal for CSV summary cells, left blank when
+// currencies are mixed.
+func combinedTotalCell(metrics summaryMetrics, format string, value float64) string {
+	if len(metrics.Currencies) > 1 {
+		return ""
+	}
+	return fmt.Sprintf(format, value)
+}
+
+func mixedCurrencyTotals(metrics summaryMetrics) []currencyTotal {
+	if len(metrics.Currencies) <= 1 {
+		return nil
+	}
+	return metrics.Currencies
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,10 +30,71 @@ type snapshotStats struct {
 	High           int
 	Medium         int
 	Low            int
+	TotalRisk      int
 	DueSoonWindow  int
+	ContentHash    string
+	// Currencies lists the award currencies in the snapshot. It is empty for
+	// snapshots written before currencies were recorded, which were USD.
+	Currencies []string
 }
 
-func syncToDatabase(items []awardItem, dueSoonDays int, dsn string) error {
+const defaultDBTimeout = 15 * time.Second
+
+func openDB(dsn string, timeout time.Duration) (*sql.DB, error) {
+	if timeout <= 0 {
+		timeout = defaultDBTimeout
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", dsnHost(dsn), err)
+	}
+	db.SetMaxOpenConns(4)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to %s: %w", dsnHost(dsn), err)
+	}
+	return db, nil
+}
+
+func dsnHost(dsn string) string {
+	if parsed, err := url.Parse(dsn); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	host, port := "", ""
+	for _, field := range strings.Fields(dsn) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "host":
+			host = value
+		case "port":
+			port = value
+		}
+	}
+	if host == "" {
+		return "database"
+	}
+	if port != "" {
+		return host + ":" + port
+	}
+	return host
+}
+
+func dbContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = defaultDBTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func resolveSyncDSN(dsn string) string {
 	dsn = strings.TrimSpace(dsn)
 	if dsn == "" {
 		dsn = strings.TrimSpace(os.Getenv("GS_PACING_DB_DSN"))
@@ -36,30 +102,98 @@ func syncToDatabase(items []awardItem, dueSoonDays int, dsn string) error {
 	if dsn == "" {
 		dsn = strings.TrimSpace(os.Getenv("DATABASE_URL"))
 	}
+	return dsn
+}
+
+func syncToDatabase(items []awardItem, dueSoonDays int, generatedAt time.Time, dsn string, retain int, timeout time.Duration, skipDuplicate bool) error {
+	dsn = resolveSyncDSN(dsn)
 	if dsn == "" {
 		return errors.New("GS_PACING_DB_DSN, DATABASE_URL, or -db-url is required for db sync")
 	}
 
-	db, err := sql.Open("pgx", dsn)
+	db, err := openDB(dsn, timeout)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := dbContext(timeout)
 	defer cancel()
 
-	if err := db.PingContext(ctx); err != nil {
+	stats := buildSnapshotStats(items, dueSoonDays, generatedAt)
+
+	if err := ensureSchema(ctx, db); err != nil {
 		return err
 	}
 
-	stats := buildSnapshotStats(items, dueSoonDays)
+	if skipDuplicate {
+		var latestID int64
+		var latestHash sql.NullString
+		err := db.QueryRowContext(ctx, `
+			SELECT id, content_hash
+			FROM groupscholar_pacing_console.pacing_snapshots
+			ORDER BY generated_at DESC, id DESC
+			LIMIT 1;
+		`).Scan(&latestID, &latestHash)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil && latestHash.Valid && latestHash.String == stats.ContentHash {
+			logger.Infof("Skipped sync: data matches Postgres snapshot %d.", latestID)
+			return nil
+		}
+	}
 
-	if err := ensureSchema(ctx, db); err != nil {
-		return err
+	return insertSnapshot(ctx, db, stats, items, retain)
+}
+
+func dryRunSync(items []awardItem, dueSoonDays int, generatedAt time.Time, dsn string, retain int, timeout time.Duration) error {
+	stats := buildSnapshotStats(items, dueSoonDays, generatedAt)
+	for _, line := range buildDryRunSummary(stats, len(items)) {
+		logger.Infof("%s", line)
+	}
+
+	dsn = resolveSyncDSN(dsn)
+	if dsn == "" {
+		logger.Infof("No database configured; skipped schema checks.")
+	} else {
+		db, err := openDB(dsn, timeout)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		ctx, cancel := dbContext(timeout)
+		defer cancel()
+
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT to_regclass('groupscholar_pacing_console.pacing_snapshots') IS NOT NULL;`).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			logger.Infof("Schema groupscholar_pacing_console is missing and would be created.")
+		} else {
+			logger.Infof("Schema groupscholar_pacing_console exists.")
+			if retain > 0 {
+				var total int
+				if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM groupscholar_pacing_console.pacing_snapshots;`).Scan(&total); err != nil {
+					return err
+				}
+				logger.Infof("Would prune %d snapshots (retaining %d).", max(total+1-retain, 0), retain)
+			}
+		}
 	}
+	logger.Infof("Dry run: nothing was written to Postgres.")
+	return nil
+}
 
-	return insertSnapshot(ctx, db, stats, items)
+func buildDryRunSummary(stats snapshotStats, awardRows int) []string {
+	return []string{
+		fmt.Sprintf("Dry run: would write snapshot at %s with %d award rows.", stats.GeneratedAt.Format(time.RFC3339), awardRows),
+		fmt.Sprintf("Awarded %0.2f · Disbursed %0.2f · Due-soon window %d days", stats.TotalAwarded, stats.TotalDisbursed, stats.DueSoonWindow),
+		fmt.Sprintf("Pace: Ahead %d · On track %d · Behind %d", stats.Ahead, stats.OnTrack, stats.Behind),
+		fmt.Sprintf("Check-ins: Overdue %d · Due soon %d", stats.Overdue, stats.DueSoon),
+		fmt.Sprintf("Risk: High %d · Medium %d · Low %d", stats.High, stats.Medium, stats.Low),
+	}
 }
 
 func ensureSchema(ctx context.Context, db *sql.DB) error {
@@ -79,7 +213,10 @@ func ensureSchema(ctx context.Context, db *sql.DB) error {
 			due_soon_count INT NOT NULL,
 			high_risk_count INT NOT NULL,
 			medium_risk_count INT NOT NULL,
-			low_risk_count INT NOT NULL
+			low_risk_count INT NOT NULL,
+			total_risk_score INT,
+			content_hash TEXT,
+			currencies TEXT
 		);`,
 		`CREATE TABLE IF NOT EXISTS groupscholar_pacing_console.pacing_awards (
 			id BIGSERIAL PRIMARY KEY,
@@ -101,8 +238,18 @@ func ensureSchema(ctx context.Context, db *sql.DB) error {
 			risk_score INT NOT NULL,
 			checkin_label TEXT NOT NULL,
 			checkin_days INT,
-			notes TEXT NOT NULL
+			notes TEXT NOT NULL,
+			risk_flags TEXT[] NOT NULL DEFAULT '{}'
 		);`,
+		`ALTER TABLE groupscholar_pacing_console.pacing_awards ADD COLUMN IF NOT EXISTS risk_flags TEXT[] NOT NULL DEFAULT '{}';`,
+		`ALTER TABLE groupscholar_pacing_console.pacing_snapshots ADD COLUMN IF NOT EXISTS content_hash TEXT;`,
+		`ALTER TABLE groupscholar_pacing_console.pacing_snapshots ADD COLUMN IF NOT EXISTS total_risk_score INT;`,
+		`ALTER TABLE groupscholar_pacing_console.pacing_snapshots ADD COLUMN IF NOT EXISTS currencies TEXT;`,
+		`UPDATE groupscholar_pacing_console.pacing_snapshots s
+			SET total_risk_score = COALESCE((
+				SELECT SUM(a.risk_score) FROM groupscholar_pacing_console.pacing_awards a WHERE a.snapshot_id = s.id
+			), 0)
+			WHERE s.total_risk_score IS NULL;`,
 		`CREATE INDEX IF NOT EXISTS pacing_awards_snapshot_idx ON groupscholar_pacing_console.pacing_awards(snapshot_id);`,
 	}
 
@@ -114,7 +261,7 @@ func ensureSchema(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
-func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items []awardItem) error {
+func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items []awardItem, retain int) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -140,8 +287,11 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 			due_soon_count,
 			high_risk_count,
 			medium_risk_count,
-			low_risk_count
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+			low_risk_count,
+			total_risk_score,
+			content_hash,
+			currencies
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)
 		RETURNING id;
 	`,
 		stats.GeneratedAt,
@@ -157,6 +307,9 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 		stats.High,
 		stats.Medium,
 		stats.Low,
+		stats.TotalRisk,
+		stats.ContentHash,
+		strings.Join(stats.Currencies, ","),
 	)
 	if err = row.Scan(&snapshotID); err != nil {
 		_ = tx.Rollback()
@@ -183,8 +336,9 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 			risk_score,
 			checkin_label,
 			checkin_days,
-			notes
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19);
+			notes,
+			risk_flags
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20);
 	`)
 	if err != nil {
 		_ = tx.Rollback()
@@ -194,13 +348,18 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 
 	for _, item := range items {
 		record := item.data
-		awardDate, _ := parseDateOptional(record.AwardDate)
-		targetDate, _ := parseDateOptional(record.TargetDate)
-		nextCheckin, _ := parseDateOptional(record.NextCheckin)
+		dates := item.dateConfig()
+		awardDate, _ := dates.ParseDate(record.AwardDate)
+		targetDate, _ := dates.ParseDate(record.TargetDate)
+		nextCheckin, _ := dates.ParseDate(record.NextCheckin)
 		checkinDays := sql.NullInt32{}
 		if item.check.Label != "Unscheduled" {
 			checkinDays = sql.NullInt32{Int32: int32(item.check.Days), Valid: true}
 		}
+		riskFlags := item.risk.Flags
+		if riskFlags == nil {
+			riskFlags = []string{}
+		}
 		_, err = insertStmt.ExecContext(
 			ctx,
 			snapshotID,
@@ -222,6 +381,7 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 			item.check.Label,
 			checkinDays,
 			record.Notes,
+			riskFlags,
 		)
 		if err != nil {
 			_ = tx.Rollback()
@@ -229,14 +389,42 @@ func insertSnapshot(ctx context.Context, db *sql.DB, stats snapshotStats, items
 		}
 	}
 
+	var pruned int64
+	if retain > 0 {
+		pruned, err = pruneSnapshots(ctx, tx, retain)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
 
-	fmt.Printf("Synced %d awards to Postgres snapshot %d.\n", len(items), snapshotID)
+	logger.Infof("Synced %d awards to Postgres snapshot %d.", len(items), snapshotID)
+	if retain > 0 {
+		logger.Infof("Pruned %d snapshots (retaining %d).", pruned, retain)
+	}
 	return nil
 }
 
+func pruneSnapshots(ctx context.Context, tx *sql.Tx, retain int) (int64, error) {
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM groupscholar_pacing_console.pacing_snapshots
+		WHERE id NOT IN (
+			SELECT id
+			FROM groupscholar_pacing_console.pacing_snapshots
+			ORDER BY generated_at DESC, id DESC
+			LIMIT $1
+		);
+	`, retain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func nullDate(parsed time.Time, raw string) sql.NullTime {
 	if raw == "" || parsed.IsZero() {
 		return sql.NullTime{Valid: false}
@@ -244,22 +432,24 @@ func nullDate(parsed time.Time, raw string) sql.NullTime {
 	return sql.NullTime{Time: parsed, Valid: true}
 }
 
-func buildSnapshotStats(items []awardItem, dueSoonDays int) snapshotStats {
+func buildSnapshotStats(items []awardItem, dueSoonDays int, generatedAt time.Time) snapshotStats {
 	stats := snapshotStats{
-		GeneratedAt:   time.Now(),
+		GeneratedAt:   generatedAt,
 		RecordCount:   len(items),
 		DueSoonWindow: dueSoonDays,
 	}
 	for _, item := range items {
 		record := item.data
-		stats.TotalAwarded += record.Amount
-		stats.TotalDisbursed += record.DisbursedToDate
+		if hasAmount(record) {
+			stats.TotalAwarded += record.Amount
+			stats.TotalDisbursed += record.DisbursedToDate
+		}
 		switch item.pace.Label {
 		case "Ahead":
 			stats.Ahead++
 		case "Behind":
 			stats.Behind++
-		default:
+		case "On Track":
 			stats.OnTrack++
 		}
 		switch item.check.Label {
@@ -268,6 +458,7 @@ func buildSnapshotStats(items []awardItem, dueSoonDays int) snapshotStats {
 		case "Due Soon":
 			stats.DueSoon++
 		}
+		stats.TotalRisk += item.risk.Score
 		switch item.risk.Level {
 		case "High":
 			stats.High++
@@ -277,33 +468,94 @@ func buildSnapshotStats(items []awardItem, dueSoonDays int) snapshotStats {
 			stats.Low++
 		}
 	}
+	for _, total := range buildCurrencyTotals(items) {
+		stats.Currencies = append(stats.Currencies, total.Currency)
+	}
+	stats.ContentHash = snapshotContentHash(items, stats)
 	return stats
 }
 
-func loadDataFromDB(dsn string) ([]Disbursement, error) {
+// snapshotContentHash fingerprints what insertSnapshot persists: every award
+// record with its pace, check-in, and risk results, plus the summary counts.
+// It ignores row order and generation time, so a re-run over unchanged data
+// hashes the same, but the same records a day later hash differently once a
+// check-in count or pace label has moved.
+func snapshotContentHash(items []awardItem, stats snapshotStats) string {
+	rows := make([]string, 0, len(items))
+	for _, item := range items {
+		encoded, _ := json.Marshal(struct {
+			Record  Disbursement
+			Pace    paceStatus
+			Checkin checkinStatus
+			Risk    riskStatus
+		}{item.data, item.pace, item.check, item.risk})
+		rows = append(rows, string(encoded))
+	}
+	sort.Strings(rows)
+	hash := sha256.New()
+	fmt.Fprintf(hash, "due_soon_window=%d\n", stats.DueSoonWindow)
+	fmt.Fprintf(hash, "counts=%d,%d,%d,%d,%d,%d,%d,%d,%d total_risk=%d\n",
+		stats.RecordCount, stats.Ahead, stats.OnTrack, stats.Behind, stats.Overdue,
+		stats.DueSoon, stats.High, stats.Medium, stats.Low, stats.TotalRisk)
+	for _, row := range rows {
+		hash.Write([]byte(row))
+		hash.Write([]byte("\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func loadDataFromDB(dsn string, timeout time.Duration, asOf time.Time) ([]Disbursement, error) {
+	records, snapshotID, generatedAt, err := loadSnapshotFromDB(dsn, timeout, asOf)
+	if err != nil {
+		return nil, err
+	}
+	logger.Notef("Loaded Postgres snapshot %d from %s.", snapshotID, generatedAt.Format(time.RFC3339))
+	return records, nil
+}
+
+// loadSnapshotFromDB is loadDataFromDB without the console note, so the TUI
+// can reload snapshots while it owns the terminal.
+func loadSnapshotFromDB(dsn string, timeout time.Duration, asOf time.Time) ([]Disbursement, int64, time.Time, error) {
 	dsn = strings.TrimSpace(dsn)
 	if dsn == "" {
-		return nil, errors.New("db-url is required to load data from Postgres")
+		return nil, 0, time.Time{}, errors.New("db-url is required to load data from Postgres")
 	}
 
-	db, err := sql.Open("pgx", dsn)
+	db, err := openDB(dsn, timeout)
 	if err != nil {
-		return nil, err
+		return nil, 0, time.Time{}, err
 	}
 	defer db.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := dbContext(timeout)
 	defer cancel()
 
-	var snapshotID int64
-	row := db.QueryRowContext(ctx, `
-		SELECT id
-		FROM groupscholar_pacing_console.pacing_snapshots
-		ORDER BY generated_at DESC
-		LIMIT 1;
-	`)
-	if err := row.Scan(&snapshotID); err != nil {
-		return nil, fmt.Errorf("load snapshot: %w", err)
+	var (
+		snapshotID  int64
+		generatedAt time.Time
+	)
+	var row *sql.Row
+	if asOf.IsZero() {
+		row = db.QueryRowContext(ctx, `
+			SELECT id, generated_at
+			FROM groupscholar_pacing_console.pacing_snapshots
+			ORDER BY generated_at DESC
+			LIMIT 1;
+		`)
+	} else {
+		row = db.QueryRowContext(ctx, `
+			SELECT id, generated_at
+			FROM groupscholar_pacing_console.pacing_snapshots
+			WHERE generated_at <= $1
+			ORDER BY generated_at DESC
+			LIMIT 1;
+		`, asOf)
+	}
+	if err := row.Scan(&snapshotID, &generatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) && !asOf.IsZero() {
+			return nil, 0, time.Time{}, fmt.Errorf("no snapshot exists at or before %s", asOf.Format(time.RFC3339))
+		}
+		return nil, 0, time.Time{}, fmt.Errorf("load snapshot: %w", err)
 	}
 
 	rows, err := db.QueryContext(ctx, `
@@ -314,7 +566,7 @@ func loadDataFromDB(dsn string) ([]Disbursement, error) {
 		ORDER BY scholar ASC;
 	`, snapshotID)
 	if err != nil {
-		return nil, err
+		return nil, 0, time.Time{}, err
 	}
 	defer rows.Close()
 
@@ -337,7 +589,7 @@ func loadDataFromDB(dsn string) ([]Disbursement, error) {
 			&nextCheckin,
 			&notes,
 		); err != nil {
-			return nil, err
+			return nil, 0, time.Time{}, err
 		}
 		records = append(records, Disbursement{
 			Scholar:         scholar,
@@ -353,9 +605,27 @@ func loadDataFromDB(dsn string) ([]Disbursement, error) {
 		})
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, 0, time.Time{}, err
 	}
-	return records, nil
+	return records, snapshotID, generatedAt, nil
+}
+
+// latestSnapshotID returns the newest snapshot id, or 0 when none exist.
+func latestSnapshotID(db *sql.DB, timeout time.Duration) (int64, error) {
+	ctx, cancel := dbContext(timeout)
+	defer cancel()
+
+	var id int64
+	err := db.QueryRowContext(ctx, `
+		SELECT id
+		FROM groupscholar_pacing_console.pacing_snapshots
+		ORDER BY generated_at DESC
+		LIMIT 1;
+	`).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
 }
 
 func formatNullableDate(value sql.NullTime) string {
@@ -365,23 +635,7 @@ func formatNullableDate(value sql.NullTime) string {
 	return value.Time.Format("2006-01-02")
 }
 
-func loadTrendSnapshots(dsn string) (snapshotStats, snapshotStats, error) {
-	dsn = strings.TrimSpace(dsn)
-	if dsn == "" {
-		return snapshotStats{}, snapshotStats{}, errors.New("db-url is required to load trend snapshots")
-	}
-
-	db, err := sql.Open("pgx", dsn)
-	if err != nil {
-		return snapshotStats{}, snapshotStats{}, err
-	}
-	defer db.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	rows, err := db.QueryContext(ctx, `
-		SELECT generated_at,
+const snapshotStatsColumns = `generated_at,
 			record_count,
 			due_soon_window,
 			total_awarded,
@@ -393,43 +647,307 @@ func loadTrendSnapshots(dsn string) (snapshotStats, snapshotStats, error) {
 			due_soon_count,
 			high_risk_count,
 			medium_risk_count,
-			low_risk_count
+			low_risk_count,
+			COALESCE(total_risk_score, 0),
+			COALESCE(currencies, '')`
+
+func scanSnapshotStats(row interface{ Scan(...any) error }) (snapshotStats, error) {
+	var (
+		stats      snapshotStats
+		currencies string
+	)
+	err := row.Scan(
+		&stats.GeneratedAt,
+		&stats.RecordCount,
+		&stats.DueSoonWindow,
+		&stats.TotalAwarded,
+		&stats.TotalDisbursed,
+		&stats.Ahead,
+		&stats.OnTrack,
+		&stats.Behind,
+		&stats.Overdue,
+		&stats.DueSoon,
+		&stats.High,
+		&stats.Medium,
+		&stats.Low,
+		&stats.TotalRisk,
+		&currencies,
+	)
+	stats.Currencies = splitCommaList(currencies)
+	return stats, err
+}
+
+// loadSnapshotStatsByID loads the totals for each snapshot id, in the order
+// given, and fails naming the first id that does not exist.
+func loadSnapshotStatsByID(dsn string, timeout time.Duration, ids ...int64) ([]snapshotStats, error) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return nil, errors.New("db-url is required to load snapshots")
+	}
+
+	db, err := openDB(dsn, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := dbContext(timeout)
+	defer cancel()
+
+	snapshots := make([]snapshotStats, 0, len(ids))
+	for _, id := range ids {
+		stats, err := scanSnapshotStats(db.QueryRowContext(ctx, `
+			SELECT `+snapshotStatsColumns+`
+			FROM groupscholar_pacing_console.pacing_snapshots
+			WHERE id = $1;
+		`, id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("snapshot %d not found", id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, stats)
+	}
+	return snapshots, nil
+}
+
+func loadTrendSnapshotSeries(dsn string, limit int, timeout time.Duration) ([]snapshotStats, error) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return nil, errors.New("db-url is required to load trend snapshots")
+	}
+	if limit < 2 {
+		return nil, errors.New("trend window must be at least 2 snapshots")
+	}
+
+	db, err := openDB(dsn, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := dbContext(timeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+snapshotStatsColumns+`
 		FROM groupscholar_pacing_console.pacing_snapshots
 		ORDER BY generated_at DESC
-		LIMIT 2;
-	`)
+		LIMIT $1;
+	`, limit)
 	if err != nil {
-		return snapshotStats{}, snapshotStats{}, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	snapshots := make([]snapshotStats, 0, 2)
+	snapshots := make([]snapshotStats, 0, limit)
 	for rows.Next() {
-		var stats snapshotStats
-		if err := rows.Scan(
-			&stats.GeneratedAt,
-			&stats.RecordCount,
-			&stats.DueSoonWindow,
-			&stats.TotalAwarded,
-			&stats.TotalDisbursed,
-			&stats.Ahead,
-			&stats.OnTrack,
-			&stats.Behind,
-			&stats.Overdue,
-			&stats.DueSoon,
-			&stats.High,
-			&stats.Medium,
-			&stats.Low,
-		); err != nil {
-			return snapshotStats{}, snapshotStats{}, err
+		stats, err := scanSnapshotStats(rows)
+		if err != nil {
+			return nil, err
 		}
 		snapshots = append(snapshots, stats)
 	}
 	if err := rows.Err(); err != nil {
-		return snapshotStats{}, snapshotStats{}, err
+		return nil, err
 	}
 	if len(snapshots) < 2 {
-		return snapshotStats{}, snapshotStats{}, errors.New("need at least two snapshots to build a trend report")
+		return nil, errors.New("need at least two snapshots to build a trend report")
+	}
+	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
+		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
+	}
+	return snapshots, nil
+}
+
+type scholarSnapshot struct {
+	ID          int64
+	GeneratedAt time.Time
+	Rows        []scholarSnapshotRow
+}
+
+type scholarSnapshotRow struct {
+	Scholar         string
+	Cohort          string
+	Owner           string
+	Amount          float64
+	DisbursedToDate float64
+	PaceLabel       string
+	RiskLevel       string
+	GapAmount       float64
+	Notes           string
+}
+
+func loadScholarSnapshotPair(dsn string, window int, timeout time.Duration) (scholarSnapshot, scholarSnapshot, error) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return scholarSnapshot{}, scholarSnapshot{}, errors.New("db-url is required to load scholar trends")
+	}
+	if window < 2 {
+		return scholarSnapshot{}, scholarSnapshot{}, errors.New("trend window must be at least 2 snapshots")
+	}
+
+	db, err := openDB(dsn, timeout)
+	if err != nil {
+		return scholarSnapshot{}, scholarSnapshot{}, err
+	}
+	defer db.Close()
+
+	ctx, cancel := dbContext(timeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, generated_at
+		FROM groupscholar_pacing_console.pacing_snapshots
+		ORDER BY generated_at DESC
+		LIMIT $1;
+	`, window)
+	if err != nil {
+		return scholarSnapshot{}, scholarSnapshot{}, err
+	}
+	snapshots := make([]scholarSnapshot, 0, window)
+	for rows.Next() {
+		var snapshot scholarSnapshot
+		if err := rows.Scan(&snapshot.ID, &snapshot.GeneratedAt); err != nil {
+			rows.Close()
+			return scholarSnapshot{}, scholarSnapshot{}, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return scholarSnapshot{}, scholarSnapshot{}, err
+	}
+	if len(snapshots) < 2 {
+		return scholarSnapshot{}, scholarSnapshot{}, errors.New("need at least two snapshots to build a trend report")
+	}
+
+	current := snapshots[0]
+	previous := snapshots[len(snapshots)-1]
+	for _, snapshot := range []*scholarSnapshot{&current, &previous} {
+		snapshot.Rows, err = loadScholarSnapshotRows(ctx, db, snapshot.ID)
+		if err != nil {
+			return scholarSnapshot{}, scholarSnapshot{}, err
+		}
+	}
+	return previous, current, nil
+}
+
+func loadScholarSnapshotRows(ctx context.Context, db *sql.DB, snapshotID int64) ([]scholarSnapshotRow, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT scholar, cohort, owner, amount, disbursed_to_date,
+			pace_label, risk_level, expected_percent, notes
+		FROM groupscholar_pacing_console.pacing_awards
+		WHERE snapshot_id = $1
+		ORDER BY scholar ASC;
+	`, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]scholarSnapshotRow, 0)
+	for rows.Next() {
+		var (
+			row             scholarSnapshotRow
+			expectedPercent float64
+		)
+		if err := rows.Scan(
+			&row.Scholar,
+			&row.Cohort,
+			&row.Owner,
+			&row.Amount,
+			&row.DisbursedToDate,
+			&row.PaceLabel,
+			&row.RiskLevel,
+			&expectedPercent,
+			&row.Notes,
+		); err != nil {
+			return nil, err
+		}
+		row.GapAmount = row.DisbursedToDate - row.Amount*expectedPercent
+		results = append(results, row)
+	}
+	return results, rows.Err()
+}
+
+func loadStalledAwards(dsn string, timeout time.Duration) (map[string]struct{}, error) {
+	dsn = resolveSyncDSN(dsn)
+	if dsn == "" {
+		return nil, errors.New("GS_PACING_DB_DSN, DATABASE_URL, or -db-url is required to detect stalled awards")
+	}
+
+	db, err := openDB(dsn, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := dbContext(timeout)
+	defer cancel()
+
+	var snapshots int
+	if err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT 1 FROM groupscholar_pacing_console.pacing_snapshots LIMIT 2
+		) recent;
+	`).Scan(&snapshots); err != nil {
+		return nil, err
+	}
+	stalled := make(map[string]struct{})
+	if snapshots < 2 {
+		logger.Notef("Stalled detection needs two snapshots; found %d.", snapshots)
+		return stalled, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		WITH recent AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY generated_at DESC, id DESC) AS position
+			FROM groupscholar_pacing_console.pacing_snapshots
+			ORDER BY generated_at DESC, id DESC
+			LIMIT 2
+		)
+		SELECT DISTINCT cur.scholar, cur.cohort
+		FROM groupscholar_pacing_console.pacing_awards cur
+		JOIN recent current_snapshot ON current_snapshot.id = cur.snapshot_id AND current_snapshot.position = 1
+		JOIN recent previous_snapshot ON previous_snapshot.position = 2
+		JOIN groupscholar_pacing_console.pacing_awards prev
+			ON prev.snapshot_id = previous_snapshot.id
+			AND LOWER(TRIM(prev.scholar)) = LOWER(TRIM(cur.scholar))
+			AND LOWER(TRIM(prev.cohort)) = LOWER(TRIM(cur.cohort))
+		WHERE cur.disbursed_to_date = prev.disbursed_to_date
+			AND cur.disbursed_to_date < cur.amount;
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record Disbursement
+		if err := rows.Scan(&record.Scholar, &record.Cohort); err != nil {
+			return nil, err
+		}
+		stalled[recordKey(record)] = struct{}{}
+	}
+	return stalled, rows.Err()
+}
+
+func parseSnapshotDate(value string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if location == nil {
+		location = time.Local
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid snapshot date %q (use YYYY-MM-DD or RFC3339)", value)
 	}
-	return snapshots[0], snapshots[1], nil
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
 }
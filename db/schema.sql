@@ -14,7 +14,9 @@ CREATE TABLE IF NOT EXISTS groupscholar_pacing_console.pacing_snapshots (
     due_soon_count INT NOT NULL,
     high_risk_count INT NOT NULL,
     medium_risk_count INT NOT NULL,
-    low_risk_count INT NOT NULL
+    low_risk_count INT NOT NULL,
+    total_risk_score INT,
+    content_hash TEXT
 );
 
 CREATE TABLE IF NOT EXISTS groupscholar_pacing_console.pacing_awards (
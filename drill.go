@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// drillDown narrows the console to one owner or cohort, entered from the
+// selected award with O or C and cleared with esc.
+type drillDown struct {
+	kind  string
+	value string
+}
+
+func (d drillDown) active() bool {
+	return d.kind != ""
+}
+
+func (d drillDown) label() string {
+	return d.kind + " " + d.value
+}
+
+func (d drillDown) matches(item awardItem) bool {
+	field := item.data.Owner
+	if d.kind == "cohort" {
+		field = item.data.Cohort
+	}
+	return strings.EqualFold(strings.TrimSpace(ownerLabel(field)), strings.TrimSpace(d.value))
+}
+
+func drillDownFor(kind string, item awardItem) drillDown {
+	value := item.data.Owner
+	if kind == "cohort" {
+		value = item.data.Cohort
+	}
+	return drillDown{kind: kind, value: strings.TrimSpace(ownerLabel(value))}
+}
+
+func applyDrillDown(items []awardItem, drill drillDown) []awardItem {
+	if !drill.active() {
+		return items
+	}
+	filtered := make([]awardItem, 0, len(items))
+	for _, item := range items {
+		if drill.matches(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// visibleItems is the list as shown: focus filter, then any drill-down, in
+// the current sort order.
+func (m model) visibleItems() []awardItem {
+	return sortItems(applyDrillDown(applyFilter(m.baseItems, m.filterMode), m.drill), m.sortMode)
+}
+
+// setDrillDown swaps the drill-down and keeps the selected award selected
+// when it is still in the list.
+func (m *model) setDrillDown(drill drillDown) {
+	selected := -1
+	if item, ok := m.list.SelectedItem().(awardItem); ok {
+		selected = item.index
+	}
+	m.drill = drill
+	m.items = m.visibleItems()
+	m.list.SetItems(itemsToList(m.items))
+	m.list.Select(0)
+	for i, item := range m.items {
+		if item.index == selected {
+			m.list.Select(i)
+			break
+		}
+	}
+}
+
+// buildDrillDownPanel rolls up every award for the owner or cohort, ignoring
+// the focus filter so the totals match the insights panel.
+func buildDrillDownPanel(items []awardItem, drill drillDown) string {
+	scoped := applyDrillDown(items, drill)
+	metrics := calculateSummaryMetrics(scoped)
+	title := "Owner"
+	if drill.kind == "cohort" {
+		title = "Cohort"
+	}
+	lines := []string{
+		fmt.Sprintf("%s: %s (esc to return)", title, drill.value),
+		fmt.Sprintf("%d awards · %d high risk · %d overdue · %d due soon", metrics.Count, metrics.High, metrics.Overdue, metrics.DueSoon),
+		fmt.Sprintf("Awarded %s · disbursed %s · gap %s",
+			formatCurrency(metrics.TotalAwarded, metrics.currency()),
+			formatCurrency(metrics.TotalDisbursed, metrics.currency()),
+			formatSignedCurrencyIn(metrics.TotalGap, metrics.currency()),
+		),
+		fmt.Sprintf("%0.1f%% complete · %s", metrics.Completion*100, formatPaceMix(metrics)),
+	}
+	if len(metrics.Currencies) > 1 {
+		lines[2] = formatCurrencyTotals(metrics.Currencies) + " (mixed currencies, not combined)"
+		lines[3] = formatPaceMix(metrics)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m model) startEdit(item awardItem) (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "YYYY-MM-DD"
+	input.CharLimit = 10
+	input.Width = 12
+	input.SetValue(item.data.NextCheckin)
+	input.CursorEnd()
+	m.editing = true
+	m.editIndex = item.index
+	m.editInput = input
+	m.editError = ""
+	return m, m.editInput.Focus()
+}
+
+func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.editing = false
+		m.editError = ""
+		m.statusMessage = "Check-in edit cancelled."
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.editInput.Value())
+		if value != "" {
+			if _, ok := m.config.ParseDate(value); !ok {
+				m.editError = fmt.Sprintf("%q is not a valid date (use YYYY-MM-DD)", value)
+				return m, nil
+			}
+		}
+		if m.editIndex < 0 || m.editIndex >= len(m.records) {
+			m.editing = false
+			return m, nil
+		}
+		m.records[m.editIndex].NextCheckin = value
+		m.unsavedEdits = true
+		m.editing = false
+		m.editError = ""
+		m.rebuildItems(m.editIndex)
+		scholar := m.records[m.editIndex].Scholar
+		if value == "" {
+			m.statusMessage = fmt.Sprintf("Cleared next check-in for %s.", scholar)
+		} else {
+			m.statusMessage = fmt.Sprintf("Next check-in for %s set to %s.", scholar, value)
+		}
+		m.refreshPanels()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.editInput, cmd = m.editInput.Update(msg)
+	m.editError = ""
+	return m, cmd
+}
+
+func (m model) buildAllItems() []awardItem {
+	return markStalledItems(buildItems(m.records, m.updatedAt, m.checkinWindowDays, m.config), m.stalled, m.config.Risk)
+}
+
+func (m *model) rebuildItems(selectRecord int) {
+	m.baseItems = applyItemFilters(m.buildAllItems(), m.filters)
+	m.items = m.visibleItems()
+	m.list.SetItems(itemsToList(m.items))
+	m.list.Select(0)
+	for i, item := range m.items {
+		if item.index == selectRecord {
+			m.list.Select(i)
+			break
+		}
+	}
+}
+
+func (m model) editView() string {
+	scholar := ""
+	if m.editIndex >= 0 && m.editIndex < len(m.records) {
+		scholar = m.records[m.editIndex].Scholar
+	}
+	line := fmt.Sprintf("Next check-in for %s: %s  %s", scholar, m.editInput.View(), subtle.Render("enter to save · esc to cancel · blank clears"))
+	if m.editError != "" {
+		line += "\n" + statusBehind.Render(m.editError)
+	}
+	return line
+}
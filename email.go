@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultSMTPPort = "587"
+
+var sendMail = smtp.SendMail
+
+type smtpSettings struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type emailDigest struct {
+	To             []string
+	Subject        string
+	Body           string
+	HTML           bool
+	AttachmentName string
+	Attachment     []byte
+}
+
+func loadSMTPSettings() (smtpSettings, error) {
+	settings := smtpSettings{
+		Host:     strings.TrimSpace(os.Getenv("PACECONSOLE_SMTP_HOST")),
+		Port:     strings.TrimSpace(os.Getenv("PACECONSOLE_SMTP_PORT")),
+		Username: strings.TrimSpace(os.Getenv("PACECONSOLE_SMTP_USERNAME")),
+		Password: os.Getenv("PACECONSOLE_SMTP_PASSWORD"),
+		From:     strings.TrimSpace(os.Getenv("PACECONSOLE_SMTP_FROM")),
+	}
+	if settings.Port == "" {
+		settings.Port = defaultSMTPPort
+	}
+	missing := make([]string, 0)
+	if settings.Host == "" {
+		missing = append(missing, "PACECONSOLE_SMTP_HOST")
+	}
+	if settings.From == "" {
+		missing = append(missing, "PACECONSOLE_SMTP_FROM")
+	}
+	if settings.Username != "" && settings.Password == "" {
+		missing = append(missing, "PACECONSOLE_SMTP_PASSWORD")
+	}
+	if len(missing) > 0 {
+		return settings, fmt.Errorf("missing SMTP settings: set %s", strings.Join(missing, ", "))
+	}
+	return settings, nil
+}
+
+func normalizeEmailFormat(format string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+	switch normalized {
+	case "", "text", "txt":
+		return "text", nil
+	case "html":
+		return "html", nil
+	}
+	return "", fmt.Errorf("unknown email format: %s (use text or html)", format)
+}
+
+func buildEmailDigest(to []string, format string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) (emailDigest, error) {
+	digest := emailDigest{
+		To:             to,
+		Subject:        fmt.Sprintf("Group Scholar pacing report · %s", generatedAt.Format("Jan 2, 2006")),
+		HTML:           format == "html",
+		AttachmentName: fmt.Sprintf("pacing-snapshot-%s.csv", generatedAt.Format("2006-01-02")),
+	}
+	if digest.HTML {
+		body, err := buildReportHTML(items, metrics, generatedAt, checkinWindow, options)
+		if err != nil {
+			return digest, err
+		}
+		digest.Body = body
+	} else {
+		digest.Body = buildReportText(items, metrics, generatedAt, checkinWindow, options)
+	}
+	var attachment bytes.Buffer
+	if err := writeSnapshotCSV(&attachment, items, metrics, generatedAt, checkinWindow, nil); err != nil {
+		return digest, err
+	}
+	digest.Attachment = attachment.Bytes()
+	return digest, nil
+}
+
+func sendEmailDigest(settings smtpSettings, digest emailDigest, sentAt time.Time) error {
+	message, err := buildEmailMessage(settings.From, digest, sentAt)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if settings.Username != "" {
+		auth = smtp.PlainAuth("", settings.Username, settings.Password, settings.Host)
+	}
+	return sendMail(net.JoinHostPort(settings.Host, settings.Port), auth, settings.From, digest.To, message)
+}
+
+func buildEmailMessage(from string, digest emailDigest, sentAt time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(digest.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", digest.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", sentAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	contentType := "text/plain; charset=utf-8"
+	if digest.HTML {
+		contentType = "text/html; charset=utf-8"
+	}
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	body := quotedprintable.NewWriter(part)
+	if _, err := body.Write([]byte(digest.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+
+	part, err = writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType("text/csv", map[string]string{"name": digest.AttachmentName})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": digest.AttachmentName})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(digest.Attachment)
+	for len(encoded) > 76 {
+		if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return nil, err
+		}
+		encoded = encoded[76:]
+	}
+	if _, err := part.Write([]byte(encoded + "\r\n")); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parseEmailRecipients(raw string) []string {
+	recipients := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		if value := strings.TrimSpace(part); value != "" {
+			recipients = append(recipients, value)
+		}
+	}
+	return recipients
+}
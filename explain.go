@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+func findScholarItem(items []awardItem, scholar string) (awardItem, error) {
+	target := strings.ToLower(strings.TrimSpace(scholar))
+	matches := make([]awardItem, 0, 1)
+	for _, item := range items {
+		if strings.ToLower(strings.TrimSpace(item.data.Scholar)) == target {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return awardItem{}, fmt.Errorf("no award found for scholar %q", scholar)
+	case 1:
+		return matches[0], nil
+	}
+	cohorts := make([]string, 0, len(matches))
+	for _, match := range matches {
+		cohorts = append(cohorts, match.data.Cohort)
+	}
+	sort.Strings(cohorts)
+	return awardItem{}, fmt.Errorf("scholar %q matches %d awards (cohorts: %s); narrow it with -cohort", scholar, len(matches), strings.Join(cohorts, ", "))
+}
+
+func buildExplanation(item awardItem, now time.Time, checkinWindow int, config pacingConfig) string {
+	if config.Location != nil {
+		now = now.In(config.Location)
+	}
+	record := item.data
+	pace := item.pace
+	risk := item.risk
+	lines := []string{
+		fmt.Sprintf("Scholar: %s · Cohort: %s · Owner: %s", record.Scholar, record.Cohort, record.Owner),
+		"",
+		fmt.Sprintf("Risk: %s (score %d; High at %d+, Medium at %d+)", risk.Level, risk.Score, config.Risk.HighThreshold, config.Risk.MediumThreshold),
+	}
+	breakdown := pacing.RiskBreakdown(risk, pace, config.Risk)
+	for _, contribution := range breakdown {
+		lines = append(lines, fmt.Sprintf("  %+d  %s", contribution.Points, contribution.Reason))
+	}
+	if len(breakdown) == 0 {
+		lines = append(lines, "  no risk flags")
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Pace: %s", pace.Label))
+	awardDate, awardOK := pacing.EffectiveStart(record, config)
+	targetDate, targetOK := config.ParseDate(record.TargetDate)
+	if awardOK && targetOK && record.GracePeriodDays > 0 {
+		lines = append(lines, fmt.Sprintf("  Grace period: %d days, so the schedule starts %s instead of %s", record.GracePeriodDays, awardDate.Format(pacing.ISODateLayout), record.AwardDate))
+	}
+	if awardOK && targetOK {
+		totalDays := max(1, targetDate.Sub(awardDate).Hours()/24)
+		lines = append(lines, fmt.Sprintf("  Schedule: %s → %s (%0.0f days); %0.0f days elapsed as of %s = %0.1f%% of time",
+			awardDate.Format(pacing.ISODateLayout),
+			targetDate.Format(pacing.ISODateLayout),
+			totalDays,
+			pace.Elapsed*totalDays,
+			now.Format(pacing.ISODateLayout),
+			pace.Elapsed*100,
+		))
+	} else {
+		lines = append(lines, fmt.Sprintf("  Schedule: award date %q, target date %q (missing or unparseable)", record.AwardDate, record.TargetDate))
+	}
+	switch pace.Label {
+	case "No Amount":
+		lines = append(lines, "  Expected: none; awards without an amount are left out of completion and gap totals")
+	case "Completed":
+		lines = append(lines, fmt.Sprintf("  Expected: what was disbursed; status %q marks the award completed, so it is not paced or checked in (-include-completed to pace it)", record.Status))
+	case "Unknown":
+		lines = append(lines, "  Expected: unknown without valid dates, milestones, or planned payments")
+	default:
+		source := "linear award-to-target schedule, capped at 100%"
+		switch pace.Basis {
+		case pacing.BasisPlanned:
+			source = "planned payments dated on or before today"
+		case pacing.BasisMilestones:
+			source = "latest milestone reached"
+		}
+		lines = append(lines,
+			fmt.Sprintf("  Expected: %0.1f%% = %s (%s)", pace.Expected*100, formatCurrency(pace.ExpectedAmount, record.Currency), source),
+		)
+	}
+	lines = append(lines,
+		fmt.Sprintf("  Actual: %0.1f%% = %s of %s", pace.Percent*100, formatCurrency(record.DisbursedToDate, record.Currency), formatCurrency(record.Amount, record.Currency)),
+	)
+	if warning := formatPaceWarning(record, pace); warning != "" {
+		lines = append(lines, "  Warning: "+warning)
+	}
+	if pace.Label != "Unknown" && pace.Label != "No Amount" && pace.Label != "Completed" {
+		lines = append(lines,
+			fmt.Sprintf("  Delta: %+0.1f pts (Ahead at %+0.1f, Behind at %+0.1f) · Gap %s",
+				pace.Delta*100,
+				config.AheadThreshold*100,
+				-config.BehindThreshold*100,
+				formatSignedCurrencyIn(pace.GapAmount, record.Currency),
+			),
+		)
+		if config.GapTolerance.Covers(pace.GapAmount, record.Amount) {
+			lines = append(lines, "  Gap is within -gap-tolerance, so the award counts as On Track")
+		}
+	}
+
+	check := item.check
+	lines = append(lines, "", fmt.Sprintf("Check-in: %s", check.Label))
+	switch {
+	case check.Label == "Unscheduled" && strings.TrimSpace(record.NextCheckin) != "":
+		lines = append(lines, fmt.Sprintf("  next_checkin %q could not be parsed", record.NextCheckin))
+	case check.Label == "Unscheduled":
+		lines = append(lines, "  no next_checkin date")
+	default:
+		lines = append(lines, fmt.Sprintf("  %s vs %s = %s (due soon within %d days)",
+			check.Date.Format(pacing.ISODateLayout),
+			now.Format(pacing.ISODateLayout),
+			formatDaysLabel(check.Days),
+			max(checkinWindow, 0),
+		))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+)
+
+type htmlCell struct {
+	Value string
+	Class string
+}
+
+type htmlSnapshotView struct {
+	GeneratedAt       string
+	CheckinWindowDays int
+	CohortWindows     string
+	Summary           [][2]string
+	Alerts            []string
+	Sections          []htmlSection
+	Columns           []string
+	Rows              [][]htmlCell
+}
+
+// htmlSection is a titled rollup table that report bodies add below the
+// summary; the plain snapshot export has none.
+type htmlSection struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+var snapshotHTMLTemplate = template.Must(template.New("snapshot").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Group Scholar Pacing Snapshot</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2933; }
+h1 { font-size: 1.4rem; margin-bottom: 0.25rem; }
+h2 { font-size: 1.1rem; margin-bottom: 0.5rem; }
+.meta { color: #616e7c; margin-bottom: 1.5rem; }
+table { border-collapse: collapse; margin-bottom: 2rem; font-size: 0.85rem; }
+th, td { border: 1px solid #d9e2ec; padding: 0.35rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f0f4f8; }
+.pace-ahead { background: #d3f9d8; color: #1b5e20; font-weight: 600; }
+.pace-on-track { background: #dbe4ff; color: #1a3a8a; font-weight: 600; }
+.pace-behind { background: #ffe3e3; color: #8a1c1c; font-weight: 600; }
+.pace-unknown { color: #616e7c; font-style: italic; }
+.risk-high { background: #ffe3e3; color: #8a1c1c; font-weight: 600; }
+.risk-medium { background: #fff3bf; color: #7a5a00; font-weight: 600; }
+.risk-low { color: #616e7c; }
+.alert { background: #ffe3e3; color: #8a1c1c; font-weight: 600; padding: 0.5rem 0.75rem; }
+</style>
+</head>
+<body>
+<h1>Group Scholar Pacing Snapshot</h1>
+<div class="meta">Generated {{.GeneratedAt}} · Check-in window {{.CheckinWindowDays}} days{{if .CohortWindows}} · Cohort check-in windows: {{.CohortWindows}}{{end}}</div>
+{{range .Alerts}}<p class="alert">{{.}}</p>
+{{end}}<table>
+<tbody>
+{{range .Summary}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</tbody>
+</table>
+{{range .Sections}}<h2>{{.Title}}</h2>
+<table>
+<thead>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}{{if .Columns}}<table>
+<thead>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td{{if .Class}} class="{{.Class}}"{{end}}>{{.Value}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}</body>
+</html>
+`))
+
+func exportSnapshotHTML(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) error {
+	content, err := renderSnapshotHTML(buildSnapshotHTMLView(items, metrics, generatedAt, checkinWindow))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// buildReportHTML renders the HTML email body. Unlike the snapshot export it
+// honours the report options the text body uses: summary-only, -report-top,
+// cohort windows and deadlines, and the owner SLA banner.
+func buildReportHTML(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) (string, error) {
+	view := buildSnapshotHTMLView(items, metrics, generatedAt, checkinWindow)
+	if len(options.CohortWindows) > 0 {
+		view.CohortWindows = formatCohortWindows(options.CohortWindows)
+	}
+	if options.SummaryOnly {
+		view.Columns = nil
+		view.Rows = nil
+		return renderSnapshotHTML(view)
+	}
+
+	ownerTop, cohortTop := reportSectionLimits(options.Top)
+	ownerSummaries := buildOwnerSummaries(items)
+	if breaches := ownerSLABreaches(ownerSummaries, options.OwnerOverdueSLA); len(breaches) > 0 {
+		view.Alerts = append(view.Alerts, formatOwnerSLALine(breaches, options.OwnerOverdueSLA))
+	}
+	owners := htmlSection{Title: "Owner pulse", Columns: []string{"owner", "awards", "high", "overdue", "gap"}}
+	for i, summary := range ownerSummaries {
+		if i >= ownerTop {
+			break
+		}
+		owners.Rows = append(owners.Rows, []string{
+			summary.Owner,
+			fmt.Sprintf("%d", summary.Awards),
+			fmt.Sprintf("%d", summary.High),
+			fmt.Sprintf("%d", summary.Overdue),
+			formatOwnerGap(summary),
+		})
+	}
+	view.Sections = append(view.Sections, owners)
+
+	cohorts := htmlSection{Title: "Cohort watchlist", Columns: []string{"cohort", "behind", "gap", "avg award complete", "dollars disbursed"}}
+	for _, summary := range buildCohortSummaries(items) {
+		if summary.Behind == 0 && summary.GapTotal >= 0 {
+			continue
+		}
+		cohorts.Rows = append(cohorts.Rows, []string{
+			summary.Cohort,
+			fmt.Sprintf("%d", summary.Behind),
+			formatSignedCurrency(summary.GapTotal),
+			fmt.Sprintf("%0.1f%%", summary.Completion*100),
+			fmt.Sprintf("%0.1f%%", summary.DollarCompletion*100),
+		})
+		if len(cohorts.Rows) >= cohortTop {
+			break
+		}
+	}
+	view.Sections = append(view.Sections, cohorts)
+
+	if deadlines := buildCohortDeadlines(items, options.CohortDeadlines, generatedAt); len(deadlines) > 0 {
+		section := htmlSection{Title: "Cohort deadlines", Columns: []string{"cohort", "deadline", "days left", "dollars disbursed", "projected", "late"}}
+		for _, deadline := range deadlines {
+			late := ""
+			if deadline.Late {
+				late = "LATE"
+			}
+			section.Rows = append(section.Rows, []string{
+				deadline.label(),
+				deadline.Deadline,
+				formatDaysLabel(deadline.DaysToDeadline),
+				fmt.Sprintf("%0.1f%%", deadline.DollarCompletion*100),
+				deadline.Projected,
+				late,
+			})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+	return renderSnapshotHTML(view)
+}
+
+func renderSnapshotHTML(view htmlSnapshotView) (string, error) {
+	var buf strings.Builder
+	if err := snapshotHTMLTemplate.Execute(&buf, view); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func buildSnapshotHTMLView(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) htmlSnapshotView {
+	summary := [][2]string{{"Awards", fmt.Sprintf("%d", metrics.Count)}}
+	summary = append(summary, summaryTotalRows(metrics)...)
+	summary = append(summary,
+		[2]string{"Pace mix", formatPaceMix(metrics)},
+		[2]string{"Risk mix", fmt.Sprintf("High %d · Medium %d · Low %d", metrics.High, metrics.Medium, metrics.Low)},
+		[2]string{"Check-ins", fmt.Sprintf("Overdue %d · Due soon %d", metrics.Overdue, metrics.DueSoon)},
+	)
+	view := htmlSnapshotView{
+		GeneratedAt:       generatedAt.Format(time.RFC3339),
+		CheckinWindowDays: checkinWindow,
+		Summary:           summary,
+		Columns:           awardExportColumns,
+		Rows:              make([][]htmlCell, 0, len(items)),
+	}
+	for _, item := range items {
+		values := awardExportRow(item)
+		row := make([]htmlCell, len(values))
+		for i, value := range values {
+			row[i] = htmlCell{Value: value}
+			switch awardExportColumns[i] {
+			case "pace_label":
+				row[i].Class = "pace-" + htmlClassName(value)
+			case "risk_level":
+				row[i].Class = "risk-" + htmlClassName(value)
+			}
+		}
+		view.Rows = append(view.Rows, row)
+	}
+	return view
+}
+
+func htmlClassName(value string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), " ", "-")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func isGzipPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".gz")
+}
+
+// loadDataGzip decompresses an archived data file; the extension before .gz
+// picks the format, and a bare .gz is read as JSON.
+func loadDataGzip(path string, lenient bool) ([]Disbursement, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip: %w", err)
+	}
+	defer reader.Close()
+
+	switch strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path)))) {
+	case ".csv":
+		return parseDisbursementCSV(reader, lenient)
+	case ".yaml", ".yml":
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return decodeDisbursementYAML(content)
+	}
+	return decodeDisbursementJSON(reader)
+}
+
+// exportSnapshotGzip writes the export named by the path without .gz into a
+// scratch directory, then compresses it into place.
+func exportSnapshotGzip(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options exportOptions) error {
+	inner := filepath.Base(strings.TrimSuffix(path, filepath.Ext(path)))
+	if filepath.Ext(inner) == "" {
+		inner += ".csv"
+	}
+	scratch, err := os.MkdirTemp("", "pacing-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(scratch)
+	plain := filepath.Join(scratch, inner)
+	if err := exportSnapshot(plain, items, metrics, generatedAt, checkinWindow, options); err != nil {
+		return err
+	}
+	return gzipFile(plain, path)
+}
+
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(out)
+	writer.Name = filepath.Base(src)
+	if _, err := io.Copy(writer, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+func buildHelpOverlay(m model) string {
+	saveState := "available"
+	if m.saveBlockReason() != "" {
+		saveState = "disabled"
+	}
+	rows := [][2]string{
+		{"/", "Filter by scholar, owner, cohort, or status"},
+		{"s", fmt.Sprintf("Cycle sort: priority → alpha → gap (now %s)", m.sortMode)},
+		{"f", fmt.Sprintf("Cycle focus: all → risk → high (now %s)", m.filterMode)},
+		{"n / N", "Jump to next / previous High-risk award"},
+		{"t", fmt.Sprintf("Toggle compact one-line rows (%s)", onOff(m.compact))},
+		{"i", fmt.Sprintf("Toggle insights panel (%s)", onOff(m.showInsights))},
+		{"c", fmt.Sprintf("Toggle cohort table (%s)", onOff(m.showCohorts))},
+		{"o", fmt.Sprintf("Toggle owner gap chart (%s)", onOff(m.showOwnerGaps))},
+		{"O / C", "Zoom to the selected award's owner / cohort (esc returns)"},
+		{"e", "Edit the selected award's next check-in"},
+		{"w", fmt.Sprintf("Write edits back to the data file (%s)", saveState)},
+		{"r", "Refresh the timestamp and recompute pacing"},
+		{"↑/↓ j/k", "Move the selection"},
+		{"?", "Toggle this help"},
+		{"q", "Quit (closes this help first)"},
+	}
+	keyWidth := 0
+	for _, row := range rows {
+		keyWidth = max(keyWidth, lipgloss.Width(row[0]))
+	}
+	lines := []string{headerStyle.Render("Keyboard shortcuts"), ""}
+	for _, row := range rows {
+		lines = append(lines, accent.Render(row[0]+strings.Repeat(" ", keyWidth-lipgloss.Width(row[0])))+"  "+row[1])
+	}
+	if reason := m.saveBlockReason(); reason != "" {
+		lines = append(lines, "", subtle.Render(reason))
+	}
+	lines = append(lines, "", subtle.Render("Press ?, q, or esc to close."))
+	return panel.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func writeCheckinCalendar(path string, items []awardItem, generatedAt time.Time) (int, error) {
+	content, count := buildCheckinCalendar(items, generatedAt)
+	return count, writeReportOutput(path, []byte(content))
+}
+
+func buildCheckinCalendar(items []awardItem, generatedAt time.Time) (string, int) {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Group Scholar//Pacing Console//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Group Scholar Check-ins",
+	}
+	stamp := generatedAt.UTC().Format("20060102T150405Z")
+	count := 0
+	for _, item := range items {
+		if item.check.Date.IsZero() {
+			continue
+		}
+		record := item.data
+		summary := fmt.Sprintf("Check-in: %s (%s)", record.Scholar, record.Owner)
+		if item.check.Label == "Overdue" {
+			summary = fmt.Sprintf("[Overdue %dd] %s", -item.check.Days, summary)
+		}
+		description := fmt.Sprintf("Cohort: %s\nPace: %s (%0.1f%% disbursed vs %0.1f%% expected)\nGap: %s\nRisk: %s",
+			record.Cohort,
+			item.pace.Label,
+			item.pace.Percent*100,
+			item.pace.Expected*100,
+			formatSignedCurrencyIn(item.pace.GapAmount, record.Currency),
+			item.risk.Level,
+		)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+checkinEventUID(record, item.check.Date),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+item.check.Date.Format("20060102"),
+			"DTEND;VALUE=DATE:"+item.check.Date.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+escapeICSText(summary),
+			"DESCRIPTION:"+escapeICSText(description),
+			"END:VEVENT",
+		)
+		count++
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	folded := make([]string, 0, len(lines))
+	for _, line := range lines {
+		folded = append(folded, foldICSLine(line))
+	}
+	return strings.Join(folded, "\r\n") + "\r\n", count
+}
+
+func checkinEventUID(record Disbursement, date time.Time) string {
+	sum := sha1.Sum([]byte(strings.ToLower(record.Scholar + "|" + record.Cohort + "|" + date.Format("2006-01-02"))))
+	return hex.EncodeToString(sum[:8]) + "@groupscholar-pacing-console"
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += size
+	}
+	return builder.String()
+}
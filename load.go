@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var requiredCSVColumns = []string{"scholar", "amount", "disbursed_to_date"}
+
+var knownCSVColumns = []string{
+	"scholar", "cohort", "amount", "disbursed_to_date", "currency",
+	"award_date", "target_date", "next_checkin", "owner", "status", "notes",
+	"grace_period_days", "last_checkin", "checkin_interval_days", "tags",
+}
+
+// loadDataFiles loads every file named by spec. lenient (-csv-lenient) loads
+// CSV files whose headers carry extra columns or loosely spelled names
+// instead of rejecting them.
+func loadDataFiles(spec string, lenient bool) ([]Disbursement, []string, error) {
+	paths, err := expandDataPaths(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(paths) == 1 {
+		records, err := loadData(paths[0], lenient)
+		return records, nil, err
+	}
+
+	type seenRecord struct {
+		path   string
+		amount float64
+	}
+	seen := make(map[string]seenRecord)
+	merged := make([]Disbursement, 0)
+	warnings := make([]string, 0)
+	for _, path := range paths {
+		records, err := loadData(path, lenient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, record := range records {
+			key := recordKey(record)
+			if first, ok := seen[key]; ok && first.path != path {
+				if first.amount != record.Amount {
+					warnings = append(warnings, fmt.Sprintf("%s (%s) amount differs: %0.2f in %s vs %0.2f in %s; keeping %s",
+						record.Scholar, record.Cohort, first.amount, first.path, record.Amount, path, first.path))
+				}
+				continue
+			}
+			seen[key] = seenRecord{path: path, amount: record.Amount}
+			merged = append(merged, record)
+		}
+	}
+	return merged, warnings, nil
+}
+
+func dedupeRecords(records []Disbursement) ([]Disbursement, []string) {
+	counts := make(map[string]int, len(records))
+	kept := make([]Disbursement, 0, len(records))
+	order := make([]Disbursement, 0)
+	for _, record := range records {
+		key := recordKey(record)
+		counts[key]++
+		if counts[key] == 1 {
+			kept = append(kept, record)
+		}
+	}
+	for _, record := range kept {
+		if counts[recordKey(record)] > 1 {
+			order = append(order, record)
+		}
+	}
+	duplicates := make([]string, 0, len(order))
+	for _, record := range order {
+		count := counts[recordKey(record)]
+		duplicates = append(duplicates, fmt.Sprintf("%s (%s) appears %d times; keeping the first, dropped %d",
+			record.Scholar, record.Cohort, count, count-1))
+	}
+	return kept, duplicates
+}
+
+func expandDataPaths(spec string) ([]string, error) {
+	paths := make([]string, 0)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !strings.ContainsAny(part, "*?[") {
+			paths = append(paths, part)
+			continue
+		}
+		matches, err := filepath.Glob(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no data files match %s", part)
+		}
+		sort.Strings(matches)
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no data files specified")
+	}
+	return paths, nil
+}
+
+func recordKey(record Disbursement) string {
+	return strings.ToLower(strings.TrimSpace(record.Scholar)) + "|" + strings.ToLower(strings.TrimSpace(record.Cohort))
+}
+
+func loadData(path string, lenient bool) ([]Disbursement, error) {
+	if isGzipPath(path) {
+		return loadDataGzip(path, lenient)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".csv" {
+		return loadDataCSV(path, lenient)
+	}
+	if ext == ".yaml" || ext == ".yml" {
+		return loadDataYAML(path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodeDisbursementJSON(file)
+}
+
+func decodeDisbursementJSON(r io.Reader) ([]Disbursement, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if token == nil {
+		return nil, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("json: expected an array of disbursements, got %v", token)
+	}
+	records := make([]Disbursement, 0)
+	for decoder.More() {
+		var record Disbursement
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("json: unexpected data after the disbursement array")
+	}
+	return records, nil
+}
+
+func loadDataYAML(path string) ([]Disbursement, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDisbursementYAML(content)
+}
+
+func decodeDisbursementYAML(content []byte) ([]Disbursement, error) {
+	var records []Disbursement
+	if err := yaml.Unmarshal(content, &records); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("invalid yaml: %s", strings.Join(typeErr.Errors, "; "))
+		}
+		return nil, fmt.Errorf("invalid yaml: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	return records, nil
+}
+
+func loadDataCSV(path string, lenient bool) ([]Disbursement, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseDisbursementCSV(file, lenient)
+}
+
+func parseDisbursementCSV(r io.Reader, lenient bool) ([]Disbursement, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv input is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		key := csvColumnKey(name, lenient)
+		if _, ok := columns[key]; !ok {
+			columns[key] = i
+		}
+	}
+	if err := checkCSVHeader(header, columns, lenient); err != nil {
+		return nil, err
+	}
+
+	records := make([]Disbursement, 0)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			index, ok := columns[name]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		amount, err := parseCSVAmount(field, "amount", line)
+		if err != nil {
+			return nil, err
+		}
+		disbursed, err := parseCSVAmount(field, "disbursed_to_date", line)
+		if err != nil {
+			return nil, err
+		}
+		grace, err := parseCSVDays(field, "grace_period_days", line)
+		if err != nil {
+			return nil, err
+		}
+		interval, err := parseCSVDays(field, "checkin_interval_days", line)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, Disbursement{
+			Scholar:             field("scholar"),
+			Cohort:              field("cohort"),
+			Amount:              amount,
+			DisbursedToDate:     disbursed,
+			Currency:            field("currency"),
+			AwardDate:           field("award_date"),
+			TargetDate:          field("target_date"),
+			NextCheckin:         field("next_checkin"),
+			Owner:               field("owner"),
+			Status:              field("status"),
+			Notes:               field("notes"),
+			GracePeriodDays:     grace,
+			LastCheckin:         field("last_checkin"),
+			CheckinIntervalDays: interval,
+			Tags:                splitCSVTags(field("tags")),
+		})
+	}
+	return records, nil
+}
+
+// csvColumnKey maps a header cell to a column name. Lenient mode also folds
+// spaces and hyphens to underscores, so "Disbursed To Date" still maps.
+func csvColumnKey(name string, lenient bool) string {
+	key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	if lenient {
+		key = strings.Join(strings.FieldsFunc(key, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '_'
+		}), "_")
+	}
+	return key
+}
+
+// checkCSVHeader rejects a header that is missing required columns or, unless
+// lenient, carries columns the loader does not know, naming both so a renamed
+// column is caught before it silently loads as empty.
+func checkCSVHeader(header []string, columns map[string]int, lenient bool) error {
+	missing := make([]string, 0, len(requiredCSVColumns))
+	for _, name := range requiredCSVColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	known := make(map[string]struct{}, len(knownCSVColumns))
+	for _, name := range knownCSVColumns {
+		known[name] = struct{}{}
+	}
+	extras := make([]string, 0)
+	for _, name := range header {
+		if _, ok := known[csvColumnKey(name, lenient)]; !ok {
+			extras = append(extras, strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		}
+	}
+	if lenient {
+		if len(extras) > 0 {
+			logger.Debugf("csv: ignoring unrecognized columns: %s", strings.Join(extras, ", "))
+		}
+		extras = nil
+	}
+	problems := make([]string, 0, 2)
+	if len(missing) > 0 {
+		problems = append(problems, "missing required columns: "+strings.Join(missing, ", "))
+	}
+	if len(extras) > 0 {
+		problems = append(problems, "unrecognized columns: "+strings.Join(extras, ", ")+" (use -csv-lenient to ignore them)")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("csv header %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// splitCSVTags reads the tags cell, separated by semicolons or commas.
+func splitCSVTags(raw string) []string {
+	tags := splitCommaList(strings.ReplaceAll(raw, ";", ","))
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+func parseCSVDays(field func(string) string, name string, line int) (int, error) {
+	raw := field(name)
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("row %d: invalid %s %q", line, name, raw)
+	}
+	return value, nil
+}
+
+func parseCSVAmount(field func(string) string, name string, line int) (float64, error) {
+	raw := field(name)
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("row %d: invalid %s %q", line, name, raw)
+	}
+	return value, nil
+}
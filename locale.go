@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// displayLocale shapes the amounts and dates people read: the summary,
+// detail panel, and reports. Machine-readable fields keep plain numbers and
+// ISO dates.
+type displayLocale struct {
+	printer     *message.Printer
+	dateLayout  string
+	shortLayout string
+	symbolAfter bool
+}
+
+var activeLocale = newDisplayLocale(language.AmericanEnglish)
+
+// applyLocale switches number grouping, date order, and symbol placement to
+// the named locale. It never changes which currency an award is in.
+func applyLocale(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	tag, err := language.Parse(name)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %v", name, err)
+	}
+	activeLocale = newDisplayLocale(tag)
+	return nil
+}
+
+func newDisplayLocale(tag language.Tag) displayLocale {
+	locale := displayLocale{printer: message.NewPrinter(tag)}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	switch {
+	case region.String() == "US":
+		locale.dateLayout, locale.shortLayout = "Jan 2, 2006", "Jan 2"
+	case base.String() == "de":
+		locale.dateLayout, locale.shortLayout = "02.01.2006", "02.01."
+		locale.symbolAfter = true
+	case base.String() == "en":
+		locale.dateLayout, locale.shortLayout = "02/01/2006", "02/01"
+	default:
+		locale.dateLayout, locale.shortLayout = "02/01/2006", "02/01"
+		locale.symbolAfter = true
+	}
+	return locale
+}
+
+func (l displayLocale) wholeNumber(value float64) string {
+	return l.printer.Sprintf("%.0f", value)
+}
+
+func (l displayLocale) decimal(value float64) string {
+	return l.printer.Sprintf("%.2f", value)
+}
+
+func (l displayLocale) money(amount, symbol string) string {
+	if l.symbolAfter {
+		return amount + " " + strings.TrimSpace(symbol)
+	}
+	return symbol + amount
+}
+
+func formatDate(t time.Time) string {
+	return t.Format(activeLocale.dateLayout)
+}
+
+func formatShortDate(t time.Time) string {
+	return t.Format(activeLocale.shortLayout)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type logLevel int
+
+const (
+	levelQuiet logLevel = iota
+	levelInfo
+	levelVerbose
+)
+
+type consoleLogger struct {
+	level  logLevel
+	out    io.Writer
+	errOut io.Writer
+}
+
+var logger = &consoleLogger{level: levelInfo, out: os.Stdout, errOut: os.Stderr}
+
+func (l *consoleLogger) Infof(format string, args ...any) {
+	if l.level >= levelInfo {
+		fmt.Fprintf(l.out, format+"\n", args...)
+	}
+}
+
+func (l *consoleLogger) Notef(format string, args ...any) {
+	if l.level >= levelInfo {
+		fmt.Fprintf(l.errOut, format+"\n", args...)
+	}
+}
+
+func (l *consoleLogger) Debugf(format string, args ...any) {
+	if l.level >= levelVerbose {
+		fmt.Fprintf(l.errOut, "debug: "+format+"\n", args...)
+	}
+}
+
+func (l *consoleLogger) Warnf(format string, args ...any) {
+	fmt.Fprintf(l.errOut, "warning: "+format+"\n", args...)
+}
+
+func (l *consoleLogger) Errorf(format string, args ...any) {
+	fmt.Fprintf(l.errOut, format+"\n", args...)
+}
@@ -5,58 +5,59 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-type Disbursement struct {
-	Scholar         string  `json:"scholar"`
-	Cohort          string  `json:"cohort"`
-	Amount          float64 `json:"amount"`
-	DisbursedToDate float64 `json:"disbursed_to_date"`
-	AwardDate       string  `json:"award_date"`
-	TargetDate      string  `json:"target_date"`
-	NextCheckin     string  `json:"next_checkin"`
-	Owner           string  `json:"owner"`
-	Status          string  `json:"status"`
-	Notes           string  `json:"notes"`
-}
+	"groupscholar-pacing-console/pkg/pacing"
+)
 
-type paceStatus struct {
-	Label          string
-	Delta          float64
-	Percent        float64
-	Expected       float64
-	ExpectedAmount float64
-	GapAmount      float64
-}
+type (
+	Disbursement   = pacing.Disbursement
+	Milestone      = pacing.Milestone
+	PlannedPayment = pacing.PlannedPayment
+	paceStatus     = pacing.PaceStatus
+	checkinStatus  = pacing.CheckinStatus
+	riskStatus     = pacing.RiskStatus
+	riskConfig     = pacing.RiskConfig
+	pacingConfig   = pacing.Config
+)
 
-type checkinStatus struct {
-	Label string
-	Days  int
-	Date  time.Time
+type awardItem struct {
+	title    string
+	desc     string
+	index    int
+	data     Disbursement
+	pace     paceStatus
+	check    checkinStatus
+	risk     riskStatus
+	forecast pacing.Forecast
+	// dateFormat is the -date-format the item was built with, for code that
+	// re-reads the record's raw dates.
+	dateFormat string
 }
 
-type awardItem struct {
-	title string
-	desc  string
-	data  Disbursement
-	pace  paceStatus
-	check checkinStatus
-	risk  riskStatus
+// dateConfig parses the item's raw record dates the way its pacing did.
+func (a awardItem) dateConfig() pacingConfig {
+	return pacingConfig{DateFormat: a.dateFormat}
 }
 
 func (a awardItem) Title() string       { return a.title }
 func (a awardItem) Description() string { return a.desc }
-func (a awardItem) FilterValue() string { return a.title }
+func (a awardItem) FilterValue() string {
+	return strings.Join([]string{a.data.Scholar, a.data.Owner, a.data.Cohort, a.data.Status}, " ")
+}
 
 type model struct {
 	list              list.Model
@@ -67,14 +68,39 @@ type model struct {
 	detail            string
 	insights          string
 	filterSummary     string
+	filters           recordFilters
 	ready             bool
 	width             int
 	height            int
 	updatedAt         time.Time
+	clock             clock
 	checkinWindowDays int
+	config            pacingConfig
+	stalled           map[string]struct{}
 	sortMode          string
 	filterMode        string
+	drill             drillDown
 	showInsights      bool
+	showCohorts       bool
+	showOwnerGaps     bool
+	showHelp          bool
+	compact           bool
+	cohortTable       string
+	cohortDeadlines   map[string]time.Time
+	ownerOverdueSLA   int
+	ownerGapChart     string
+	editing           bool
+	unsavedEdits      bool
+	editIndex         int
+	editInput         textinput.Model
+	editError         string
+	statusMessage     string
+	dataPath          string
+	dataSource        string
+	saveBlocked       string
+	statePath         string
+	windowFromFlag    bool
+	watch             dataWatch
 }
 
 type summaryMetrics struct {
@@ -83,10 +109,16 @@ type summaryMetrics struct {
 	TotalDisbursed float64
 	TotalExpected  float64
 	TotalGap       float64
+	TotalRemaining float64
 	Completion     float64
 	Ahead          int
 	OnTrack        int
 	Behind         int
+	Unknown        int
+	NoAmount       int
+	Completed      int
+	Warnings       int
+	Currencies     []currencyTotal
 	Overdue        int
 	DueSoon        int
 	High           int
@@ -95,120 +127,648 @@ type summaryMetrics struct {
 	Upcoming       []string
 }
 
-type riskStatus struct {
-	Level string
-	Flags []string
-	Score int
-}
-
 type recordFilters struct {
-	owners   map[string]struct{}
-	cohorts  map[string]struct{}
-	statuses map[string]struct{}
-}
-
-var (
-	accent       = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
-	subtle       = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	panel        = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2)
-	headerStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Bold(true)
-	statusAhead  = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
-	statusOn     = lipgloss.NewStyle().Foreground(lipgloss.Color("69")).Bold(true)
-	statusBehind = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
-)
+	owners    map[string]struct{}
+	cohorts   map[string]struct{}
+	statuses  map[string]struct{}
+	tags      map[string]struct{}
+	tagMode   string
+	minAmount float64
+	maxAmount float64
+	paces     map[string]struct{}
+	risks     map[string]struct{}
+	// minDaysOverdue keeps only check-ins overdue by at least this many
+	// days; 0 disables it.
+	minDaysOverdue int
+}
 
 func main() {
-	dataPath := flag.String("data", "data/disbursements.json", "path to disbursement data")
+	dataPath := flag.String("data", "data/disbursements.json", "path to disbursement data (json, yaml, or csv); comma-separated paths or globs are merged")
 	defaultDBURL := os.Getenv("PACECONSOLE_DATABASE_URL")
 	if defaultDBURL == "" {
 		defaultDBURL = os.Getenv("DATABASE_URL")
 	}
 	dbURL := flag.String("db-url", defaultDBURL, "Postgres connection string (optional)")
+	detectStalled := flag.Bool("detect-stalled", false, "flag awards whose disbursed_to_date did not change across the last two Postgres snapshots")
+	dbTimeout := flag.Duration("db-timeout", defaultDBTimeout, "timeout for Postgres connections and queries (e.g. 30s, 2m)")
 	checkinWindow := flag.Int("checkin-window", 14, "days before a check-in is considered due soon")
+	checkinInterval := flag.Int("checkin-interval", 0, "flag awards whose last_checkin is more than this many days ago (0 disables; checkin_interval_days overrides per award)")
+	aheadThreshold := flag.Float64("ahead-threshold", 0.1, "pace delta at or above which an award is ahead")
+	behindThreshold := flag.Float64("behind-threshold", 0.1, "pace delta shortfall at or beyond which an award is behind")
+	completedStatuses := flag.String("completed-statuses", strings.Join(pacing.DefaultCompletedStatuses, ","), "record statuses that mark an award finished: counted in totals and as Completed, but never Behind or Overdue")
+	includeCompleted := flag.Bool("include-completed", false, "pace awards with a completed status like any other award")
+	gapTolerance := flag.String("gap-tolerance", "", "treat awards within this gap of expected as On Track: dollars (250) or percent of amount (1%)")
+	timezone := flag.String("timezone", "", "IANA timezone for check-in day math, e.g. America/Chicago (default local time)")
+	cohortConfigPath := flag.String("cohort-config", "", "path to a JSON file of cohort metadata, e.g. {\"Spring 2025\": {\"deadline\": \"2026-06-30\"}}")
+	riskConfigPath := flag.String("risk-config", "", "path to a JSON file overriding risk weights and thresholds")
+	dateFormat := flag.String("date-format", "", "Go layout to pin for ambiguous record dates, e.g. 02/01/2006 (default tries ISO, then common spreadsheet formats)")
+	nowFlag := flag.String("now", "", "reference time for pacing, check-ins, and report timestamps (RFC3339, or YYYY-MM-DD for midnight); defaults to the current time")
+	snapshotDate := flag.String("snapshot-date", "", "with -source db, load the latest snapshot at or before this date (YYYY-MM-DD, end of day) or RFC3339 time")
 	source := flag.String("source", "file", "data source: file or db")
+	useStdin := flag.Bool("stdin", false, "read disbursement JSON from stdin (same as -data -)")
 	dbSync := flag.Bool("db-sync", false, "write a pacing snapshot to Postgres (requires GS_PACING_DB_DSN or -db-url)")
-	exportPath := flag.String("export", "", "export snapshot to csv or json (path)")
+	dryRun := flag.Bool("dry-run", false, "with -db-sync, show what would be written without changing the database")
+	dbRetain := flag.Int("db-retain", 0, "after db-sync, keep only the most recent N snapshots (0 keeps all)")
+	dbSkipDuplicate := flag.Bool("db-skip-duplicate", false, "with -db-sync, skip the insert when the newest snapshot has identical data")
+	dbSyncAnonymize := flag.Bool("db-sync-anonymize", false, "with -db-sync, write pseudonymous scholar names and redacted notes")
+	exportPath := flag.String("export", "", "export snapshot to csv, json, jsonl, or html (path)")
+	exportJSONLSummary := flag.Bool("export-jsonl-summary", false, "write the summary as the first line of a jsonl/ndjson export")
 	exportFilter := flag.String("export-filter", "all", "export filter: all, risk, high")
+	sortFlag := flag.String("sort", "priority", "row order for -export and -report: priority, alpha, or gap (matches the console's s key)")
+	exportView := flag.String("export-view", "awards", "export view: awards, owners or cohorts (.csv rollups), summary (.json totals only), or attention (.json flagged awards in priority order)")
+	exportColumns := flag.String("export-columns", "", "comma-separated award columns, in order, for a .csv export (default all)")
 	reportPath := flag.String("report", "", "write a pacing report to txt or json (path or stdout)")
-	reportFormat := flag.String("report-format", "", "report format: text or json (optional)")
+	reportSplitBy := flag.String("report-split-by", "", "write one report per owner or cohort (requires -report path)")
+	reportFormat := flag.String("report-format", "", "report format: text, json, markdown, or table (optional)")
+	reportTop := flag.Int("report-top", 0, "owners and cohorts listed in text/markdown reports (default 5 owners, 4 cohorts; JSON lists all)")
+	reportSummaryOnly := flag.Bool("report-summary-only", false, "write only the summary block in text, markdown, and JSON reports (and email bodies)")
 	trendReportPath := flag.String("trend-report", "", "write a pacing trend report from the latest two snapshots (path or stdout)")
 	trendReportFormat := flag.String("trend-format", "", "trend report format: text or json (optional)")
-	actionPlanPath := flag.String("action-plan", "", "write an action plan to txt or json (path or stdout)")
-	actionPlanFormat := flag.String("action-format", "", "action plan format: text or json (optional)")
-	actionPlanFilter := flag.String("action-filter", "risk", "action plan filter: all, risk, high")
+	trendWindow := flag.Int("trend-window", 2, "number of recent snapshots to include in the trend report")
+	trendFiles := flag.String("trend-files", "", "build the trend report from exported snapshot JSON files (directory, glob, or comma-separated paths) instead of Postgres")
+	diffSnapshots := flag.String("diff-snapshots", "", "compare two Postgres snapshots by id, baseline first (e.g. 7,12); writes the trend report to -trend-report or stdout")
+	trendMode := flag.String("trend-mode", "aggregate", "trend report mode: aggregate or scholar")
+	lenientCSV := flag.Bool("csv-lenient", false, "load CSV files with unrecognized columns (ignored) and loosely spelled headers such as \"Disbursed To Date\"")
+	strict := flag.Bool("strict", false, "fail instead of warning when the data contains duplicate scholar + cohort records")
+	validateOnly := flag.Bool("validate", false, "validate disbursement records, print issues, and exit non-zero if any are found")
+	slackWebhook := flag.String("slack-webhook", "", "post high-risk and overdue awards to a Slack incoming webhook URL")
+	slackFilter := flag.String("slack-filter", "all", "slack filter: all, risk, high")
+	slackMentionsPath := flag.String("slack-mentions", "", "path to a JSON map of owner name to Slack user ID for mentions")
+	webhookURL := flag.String("webhook-url", "", "POST the JSON snapshot (same payload as -export .json) to this URL")
+	webhookContentType := flag.String("webhook-content-type", "application/json", "Content-Type header for -webhook-url")
+	webhookAuthEnv := flag.String("webhook-auth-env", "PACECONSOLE_WEBHOOK_AUTH", "environment variable holding an optional Authorization header value for -webhook-url")
+	emailTo := flag.String("email-to", "", "email the report to these comma-separated addresses with the CSV export attached (SMTP settings from PACECONSOLE_SMTP_* env vars)")
+	emailFormat := flag.String("email-format", "text", "email body format: text or html")
+	serveAddr := flag.String("serve", "", "serve /snapshot, /report, and /healthz over HTTP on this address (e.g. :8080)")
+	metricsAddr := flag.String("metrics", "", "serve Prometheus gauges on /metrics at this address (e.g. :9090)")
+	serveReload := flag.Bool("serve-reload", false, "with -serve or -metrics, re-read the data on every request instead of once at startup")
+	anonymize := flag.Bool("anonymize", false, "replace scholar names with stable pseudonyms and redact notes in exports, reports, and other batch outputs")
+	anonymizeAll := flag.Bool("anonymize-all", false, "like -anonymize, and also pseudonymize owners and cohorts")
+	outputDir := flag.String("output-dir", "", "write the export, report, and calendar together into this directory (created if missing); bare file names in -export/-report/-ics land inside it")
+	icsPath := flag.String("ics", "", "write an iCalendar file of scheduled check-ins (path or stdout)")
 	ownerFilter := flag.String("owner", "", "filter to specific owner(s), comma-separated")
 	cohortFilter := flag.String("cohort", "", "filter to specific cohort(s), comma-separated")
 	statusFilter := flag.String("status", "", "filter to specific status values, comma-separated")
+	minAmount := flag.Float64("min-amount", 0, "only include awards with amount at or above this value (0 for no bound)")
+	maxAmount := flag.Float64("max-amount", 0, "only include awards with amount at or below this value (0 for no bound)")
+	paceFilter := flag.String("pace", "", "filter to pace labels (Ahead, On Track, Behind, Unknown), comma-separated")
+	tagFilter := flag.String("tag", "", "filter to awards carrying these tag(s), comma-separated")
+	tagMode := flag.String("tag-mode", "any", "with -tag, keep awards matching any listed tag or all of them: any or all")
+	minDaysOverdue := flag.Int("min-days-overdue", 0, "only include awards whose check-in is overdue by at least N days (0 for no filter)")
+	riskFilter := flag.String("risk", "", "filter to risk levels (High, Medium, Low), comma-separated")
+	showVersion := flag.Bool("version", false, "print version information and exit")
+	themeName := flag.String("theme", "default", "color theme: default, colorblind, or mono")
+	localeName := flag.String("locale", "", "number, currency, and date formatting for the console and reports, e.g. de-DE or en-GB (default keeps en-US)")
+	configPath := flag.String("config", "", "path to a JSON file of default flag values (command-line flags win)")
+	failOnHigh := flag.Int("fail-on-high", -1, "in batch modes, exit 2 when more than N awards are High risk (-1 disables)")
+	failOnOverdue := flag.Int("fail-on-overdue", -1, "in batch modes, exit 2 when more than N check-ins are overdue (-1 disables)")
+	ownerOverdueSLA := flag.Int("owner-overdue-sla", -1, "flag owners carrying more than N overdue check-ins in reports and insights (-1 disables)")
+	failOnOwnerSLA := flag.Bool("fail-on-owner-sla", false, "in batch modes, exit 2 when any owner breaches -owner-overdue-sla")
+	quiet := flag.Bool("quiet", false, "suppress informational output (errors still go to stderr)")
+	verbose := flag.Bool("verbose", false, "log data load counts, filter effects, and timing to stderr")
+	explain := flag.String("explain", "", "print the risk, pace, and check-in math for one scholar (case-insensitive) and exit")
+	compact := flag.Bool("compact", false, "start the TUI with one line per award (toggle with t)")
+	watch := flag.Bool("watch", false, "in the TUI, reload when the -data file changes (or, with -source db, when a new snapshot lands)")
+	watchInterval := flag.Duration("watch-interval", defaultWatchInterval, "how often -watch checks for changes")
+	pollInterval := flag.Duration("poll", 0, "with -source db, check for a newer snapshot this often (e.g. 5m) and refresh the TUI when one lands")
+	cpuProfile := flag.String("cpuprofile", "", "write a pprof CPU profile to this path")
+	memProfile := flag.String("memprofile", "", "write a pprof heap profile to this path on exit")
+	noState := flag.Bool("no-state", false, "do not restore or save TUI preferences (sort, focus, insights, check-in window)")
 	flag.Parse()
+	started := time.Now()
+
+	if *showVersion {
+		fmt.Println(versionString())
+		return
+	}
+	if strings.TrimSpace(*configPath) != "" {
+		if err := applyConfigFile(flag.CommandLine, *configPath); err != nil {
+			logger.Errorf("error loading config: %v", err)
+			exit(1)
+		}
+	}
+
+	if *quiet && *verbose {
+		logger.Errorf("error: -quiet and -verbose cannot be combined")
+		exit(1)
+	}
+	if *quiet {
+		logger.level = levelQuiet
+	} else if *verbose {
+		logger.level = levelVerbose
+	}
+	if err := startProfiling(strings.TrimSpace(*cpuProfile), strings.TrimSpace(*memProfile)); err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	defer stopProfiling()
+
+	if err := applyTheme(*themeName); err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	if err := applyLocale(*localeName); err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
 
+	if *reportTop < 0 {
+		logger.Errorf("error: -report-top must be zero or positive, got %d", *reportTop)
+		exit(1)
+	}
+	emailRecipients := parseEmailRecipients(*emailTo)
+	var smtpConfig smtpSettings
+	emailBodyFormat := "text"
+	if len(emailRecipients) > 0 {
+		var err error
+		if smtpConfig, err = loadSMTPSettings(); err != nil {
+			logger.Errorf("error: %v", err)
+			exit(1)
+		}
+		if emailBodyFormat, err = normalizeEmailFormat(*emailFormat); err != nil {
+			logger.Errorf("error: %v", err)
+			exit(1)
+		}
+	}
+	reportOpts := reportOptions{Top: *reportTop, SummaryOnly: *reportSummaryOnly, OwnerOverdueSLA: *ownerOverdueSLA}
+	if *failOnOwnerSLA && *ownerOverdueSLA < 0 {
+		logger.Errorf("error: -fail-on-owner-sla requires -owner-overdue-sla")
+		exit(1)
+	}
+	batchSort, err := normalizeSortMode(*sortFlag)
+	if err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	config := pacing.DefaultConfig()
+	config.DateFormat = strings.TrimSpace(*dateFormat)
+	config.AheadThreshold = *aheadThreshold
+	config.BehindThreshold = *behindThreshold
+	config.CheckinInterval = *checkinInterval
+	config.CompletedStatuses = splitCommaList(*completedStatuses)
+	if *includeCompleted {
+		config.CompletedStatuses = nil
+	}
+	if tolerance, err := pacing.ParseGapTolerance(*gapTolerance); err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	} else {
+		config.GapTolerance = tolerance
+	}
+	if strings.TrimSpace(*timezone) != "" {
+		location, err := time.LoadLocation(strings.TrimSpace(*timezone))
+		if err != nil {
+			logger.Errorf("error: invalid timezone: %v", err)
+			exit(1)
+		}
+		config.Location = location
+	}
+	if strings.TrimSpace(*riskConfigPath) != "" {
+		riskCfg, err := loadRiskConfig(*riskConfigPath)
+		if err != nil {
+			logger.Errorf("error loading risk config: %v", err)
+			exit(1)
+		}
+		config.Risk = riskCfg
+	}
+	if err := pacing.ValidateConfig(config); err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	if strings.TrimSpace(*cohortConfigPath) != "" {
+		cohorts, err := loadCohortConfig(*cohortConfigPath, config)
+		if err != nil {
+			logger.Errorf("error loading cohort config: %v", err)
+			exit(1)
+		}
+		reportOpts.CohortDeadlines = cohorts.Deadlines
+		reportOpts.CohortWindows = cohorts.Windows
+		config.CohortCheckinWindows = cohorts.CheckinWindows
+	}
+	clk, err := parseClock(*nowFlag, config.Location)
+	if err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	now := clk.Now()
+	var anon *anonymizer
+	if *anonymize || *anonymizeAll || *dbSyncAnonymize {
+		anon, err = newRunAnonymizer(*anonymizeAll, *dbSyncAnonymize)
+		if err != nil {
+			logger.Errorf("error preparing anonymizer: %v", err)
+			exit(1)
+		}
+	}
+
+	if strings.TrimSpace(*diffSnapshots) != "" {
+		baseID, compareID, err := parseSnapshotIDPair(*diffSnapshots)
+		if err != nil {
+			logger.Errorf("error: %v", err)
+			exit(1)
+		}
+		snapshots, err := loadSnapshotStatsByID(*dbURL, *dbTimeout, baseID, compareID)
+		if err != nil {
+			logger.Errorf("error loading snapshots: %v", err)
+			exit(1)
+		}
+		path := *trendReportPath
+		if strings.TrimSpace(path) == "" {
+			path = "-"
+		}
+		if err := writeTrendReport(path, *trendReportFormat, snapshots[1], snapshots[0], now); err != nil {
+			logger.Errorf("error writing trend report: %v", err)
+			exit(1)
+		}
+		if !isStdoutTarget(path) {
+			logger.Infof("Wrote trend report to %s", path)
+		}
+		return
+	}
+	if strings.TrimSpace(*trendReportPath) != "" && strings.EqualFold(strings.TrimSpace(*trendMode), "scholar") {
+		var previous, current scholarSnapshot
+		var err error
+		if strings.TrimSpace(*trendFiles) != "" {
+			previous, current, err = loadScholarSnapshotPairFromFiles(*trendFiles, *trendWindow)
+		} else {
+			previous, current, err = loadScholarSnapshotPair(*dbURL, *trendWindow, *dbTimeout)
+		}
+		if err != nil {
+			logger.Errorf("error loading trend snapshots: %v", err)
+			exit(1)
+		}
+		if err := writeScholarTrendReport(*trendReportPath, *trendReportFormat, previous, current, now, anon); err != nil {
+			logger.Errorf("error writing trend report: %v", err)
+			exit(1)
+		}
+		if !isStdoutTarget(*trendReportPath) {
+			logger.Infof("Wrote trend report to %s", *trendReportPath)
+		}
+		return
+	}
 	if strings.TrimSpace(*trendReportPath) != "" {
-		current, previous, err := loadTrendSnapshots(*dbURL)
+		var series []snapshotStats
+		var err error
+		if strings.TrimSpace(*trendFiles) != "" {
+			series, err = loadTrendSnapshotSeriesFromFiles(*trendFiles, *trendWindow)
+		} else {
+			series, err = loadTrendSnapshotSeries(*dbURL, *trendWindow, *dbTimeout)
+		}
 		if err != nil {
-			fmt.Println("error loading trend snapshots:", err)
-			os.Exit(1)
+			logger.Errorf("error loading trend snapshots: %v", err)
+			exit(1)
 		}
-		if err := writeTrendReport(*trendReportPath, *trendReportFormat, current, previous, time.Now()); err != nil {
-			fmt.Println("error writing trend report:", err)
-			os.Exit(1)
+		if len(series) == 2 {
+			err = writeTrendReport(*trendReportPath, *trendReportFormat, series[1], series[0], now)
+		} else {
+			err = writeTrendSeriesReport(*trendReportPath, *trendReportFormat, series, now)
+		}
+		if err != nil {
+			logger.Errorf("error writing trend report: %v", err)
+			exit(1)
 		}
 		if !isStdoutTarget(*trendReportPath) {
-			fmt.Printf("Wrote trend report to %s\n", *trendReportPath)
+			logger.Infof("Wrote trend report to %s", *trendReportPath)
 		}
 		return
 	}
 
-	var (
-		records []Disbursement
-		err     error
-	)
+	var records []Disbursement
+	readStdin := *useStdin || strings.TrimSpace(*dataPath) == "-"
+	dataSource := "file"
 	if strings.EqualFold(*source, "db") {
-		records, err = loadDataFromDB(*dbURL)
+		if readStdin {
+			logger.Warnf("-stdin is ignored when -source db is set")
+			readStdin = false
+		}
+		dataSource = "db"
+		var asOf time.Time
+		asOf, err = parseSnapshotDate(*snapshotDate, config.Location)
+		if err == nil {
+			records, err = loadDataFromDB(*dbURL, *dbTimeout, asOf)
+		}
+	} else if readStdin {
+		dataSource = "stdin"
+		records, err = decodeDisbursementJSON(os.Stdin)
 	} else {
-		records, err = loadData(*dataPath)
+		var warnings []string
+		records, warnings, err = loadDataFiles(*dataPath, *lenientCSV)
+		for _, warning := range warnings {
+			logger.Warnf("%s", warning)
+		}
 	}
 	if err != nil {
-		fmt.Println("error loading data:", err)
-		os.Exit(1)
+		logger.Errorf("error loading data: %v", err)
+		exit(1)
+	}
+	logger.Debugf("loaded %d records from %s in %s", len(records), dataSource, time.Since(started).Round(time.Millisecond))
+	records, duplicates := dedupeRecords(records)
+	if len(duplicates) > 0 && *strict {
+		for _, duplicate := range duplicates {
+			logger.Errorf("duplicate: %s", duplicate)
+		}
+		logger.Errorf("error loading data: %d duplicate scholar records found (-strict)", len(duplicates))
+		exit(1)
+	}
+	for _, duplicate := range duplicates {
+		logger.Warnf("duplicate %s", duplicate)
+	}
+
+	issues := validateRecords(records, config)
+	if *validateOnly {
+		for _, issue := range issues {
+			fmt.Println(issue.String())
+		}
+		if len(issues) > 0 {
+			fmt.Printf("Found %d validation issues in %d records.\n", len(issues), len(records))
+			exit(1)
+		}
+		logger.Infof("Validated %d records with no issues.", len(records))
+		return
+	}
+	if len(issues) > 0 {
+		logger.Warnf("%d data validation issues found (run with -validate for details)", len(issues))
 	}
 
-	now := time.Now()
 	filters := parseRecordFilters(*ownerFilter, *cohortFilter, *statusFilter)
+	filters.minAmount = *minAmount
+	filters.maxAmount = *maxAmount
+	filters.paces = parseFilterList(*paceFilter)
+	filters.risks = parseFilterList(*riskFilter)
+	filters.tags = parseFilterList(*tagFilter)
+	filters.minDaysOverdue = *minDaysOverdue
+	filters.tagMode, err = normalizeTagMode(*tagMode)
+	if err != nil {
+		logger.Errorf("error: %v", err)
+		exit(1)
+	}
+	loaded := len(records)
 	records = applyRecordFilters(records, filters)
-	baseItems := buildItems(records, now, *checkinWindow)
+	logger.Debugf("record filters kept %d of %d records", len(records), loaded)
+	pacingStarted := time.Now()
+	allItems := buildItems(records, now, *checkinWindow, config)
+	var stalled map[string]struct{}
+	if *detectStalled {
+		stalled, err = loadStalledAwards(*dbURL, *dbTimeout)
+		if err != nil {
+			logger.Errorf("error detecting stalled awards: %v", err)
+			exit(1)
+		}
+		allItems = markStalledItems(allItems, stalled, config.Risk)
+		logger.Debugf("flagged %d stalled awards", len(buildStalledAwards(allItems)))
+	}
+	baseItems := applyItemFilters(allItems, filters)
+	logger.Debugf("pace/risk filters kept %d of %d awards", len(baseItems), len(allItems))
+	logger.Debugf("computed pacing for %d awards in %s", len(allItems), time.Since(pacingStarted).Round(time.Millisecond))
+	anonymizeOutputs := *anonymize || *anonymizeAll
+	outputItems := baseItems
+	if anonymizeOutputs {
+		outputItems = anon.items(baseItems)
+	}
+	enforceThresholds := func() {
+		tripped := checkFailThresholds(calculateSummaryMetrics(baseItems), *failOnHigh, *failOnOverdue)
+		if *failOnOwnerSLA {
+			tripped = append(tripped, checkOwnerSLA(outputItems, *ownerOverdueSLA)...)
+		}
+		for _, message := range tripped {
+			logger.Errorf("threshold exceeded: %s", message)
+		}
+		if len(tripped) > 0 {
+			exit(2)
+		}
+	}
+	if strings.TrimSpace(*explain) != "" {
+		item, err := findScholarItem(allItems, *explain)
+		if err != nil {
+			logger.Errorf("error: %v", err)
+			exit(1)
+		}
+		fmt.Print(buildExplanation(item, now, *checkinWindow, config))
+		return
+	}
+	if strings.TrimSpace(*serveAddr) != "" || strings.TrimSpace(*metricsAddr) != "" {
+		server := &snapshotServer{
+			items:         outputItems,
+			generatedAt:   now,
+			checkinWindow: *checkinWindow,
+			options:       reportOpts,
+		}
+		if *serveReload {
+			if dataSource == "stdin" {
+				logger.Errorf("error: -serve-reload cannot re-read stdin")
+				exit(1)
+			}
+			server.reload = func() ([]awardItem, time.Time, error) {
+				var records []Disbursement
+				var err error
+				if dataSource == "db" {
+					var asOf time.Time
+					if asOf, err = parseSnapshotDate(*snapshotDate, config.Location); err == nil {
+						records, _, _, err = loadSnapshotFromDB(*dbURL, *dbTimeout, asOf)
+					}
+				} else {
+					records, _, err = loadDataFiles(*dataPath, *lenientCSV)
+				}
+				if err != nil {
+					return nil, time.Time{}, err
+				}
+				records, _ = dedupeRecords(records)
+				generatedAt := clk.Now()
+				items := buildItems(applyRecordFilters(records, filters), generatedAt, *checkinWindow, config)
+				if *detectStalled {
+					stalled, err := loadStalledAwards(*dbURL, *dbTimeout)
+					if err != nil {
+						return nil, time.Time{}, err
+					}
+					items = markStalledItems(items, stalled, config.Risk)
+				}
+				items = applyItemFilters(items, filters)
+				if anonymizeOutputs {
+					items = anon.items(items)
+				}
+				return items, generatedAt, nil
+			}
+		}
+		servers := make([]*http.Server, 0, 2)
+		if strings.TrimSpace(*serveAddr) != "" {
+			servers = append(servers, newHTTPServer(*serveAddr, server.handler()))
+			logger.Infof("Serving %d awards on %s (/snapshot, /report, /metrics, /healthz)", len(baseItems), *serveAddr)
+		}
+		if strings.TrimSpace(*metricsAddr) != "" {
+			servers = append(servers, newHTTPServer(*metricsAddr, server.metricsHandler()))
+			logger.Infof("Serving metrics on %s (/metrics, /healthz)", *metricsAddr)
+		}
+		if err := serveSnapshots(servers...); err != nil {
+			logger.Errorf("error serving: %v", err)
+			exit(1)
+		}
+		logger.Infof("Server stopped")
+		return
+	}
 	if *dbSync {
-		if err := syncToDatabase(baseItems, *checkinWindow, *dbURL); err != nil {
-			fmt.Println("error syncing database:", err)
-			os.Exit(1)
+		var err error
+		syncItems := baseItems
+		if *dbSyncAnonymize {
+			syncItems = anon.items(baseItems)
+		}
+		if *dryRun {
+			err = dryRunSync(syncItems, *checkinWindow, now, *dbURL, *dbRetain, *dbTimeout)
+		} else {
+			err = syncToDatabase(syncItems, *checkinWindow, now, *dbURL, *dbRetain, *dbTimeout, *dbSkipDuplicate)
+		}
+		if err != nil {
+			logger.Errorf("error syncing database: %v", err)
+			exit(1)
 		}
+		enforceThresholds()
 		return
 	}
-	if strings.TrimSpace(*exportPath) != "" {
+	if strings.TrimSpace(*slackWebhook) != "" {
+		filterMode, err := normalizeFilterMode(*slackFilter)
+		if err != nil {
+			logger.Errorf("error posting to slack: %v", err)
+			exit(1)
+		}
+		var mentions map[string]string
+		if strings.TrimSpace(*slackMentionsPath) != "" {
+			mentions, err = loadSlackMentions(*slackMentionsPath)
+			if err != nil {
+				logger.Errorf("error loading slack mentions: %v", err)
+				exit(1)
+			}
+		}
+		items := sortItems(applyFilter(outputItems, filterMode), "priority")
+		count, err := notifySlack(*slackWebhook, items, now, mentions)
+		if err != nil {
+			logger.Errorf("error posting to slack: %v", err)
+			exit(1)
+		}
+		logger.Infof("Posted %d flagged awards to Slack", count)
+		enforceThresholds()
+		return
+	}
+	if strings.TrimSpace(*webhookURL) != "" {
 		filterMode, err := normalizeFilterMode(*exportFilter)
 		if err != nil {
-			fmt.Println("error exporting snapshot:", err)
-			os.Exit(1)
+			logger.Errorf("error posting snapshot: %v", err)
+			exit(1)
 		}
-		items := sortItems(applyFilter(baseItems, filterMode), "priority")
+		items := sortItems(applyFilter(outputItems, filterMode), "priority")
 		metrics := calculateSummaryMetrics(items)
-		if err := exportSnapshot(*exportPath, items, metrics, now, *checkinWindow); err != nil {
-			fmt.Println("error exporting snapshot:", err)
-			os.Exit(1)
+		options := webhookOptions{
+			ContentType:   *webhookContentType,
+			Authorization: os.Getenv(*webhookAuthEnv),
 		}
-		fmt.Printf("Exported %d awards to %s\n", len(items), *exportPath)
+		status, err := postSnapshotWebhook(*webhookURL, items, metrics, now, *checkinWindow, options)
+		if err != nil {
+			logger.Errorf("error posting snapshot: %v", err)
+			exit(1)
+		}
+		logger.Infof("Posted %d awards to webhook (%s)", len(items), status)
+		enforceThresholds()
 		return
 	}
-	if strings.TrimSpace(*reportPath) != "" {
-		items := sortItems(applyFilter(baseItems, "all"), "priority")
+	if len(emailRecipients) > 0 {
+		items := sortItems(applyFilter(outputItems, "all"), "priority")
+		metrics := calculateSummaryMetrics(items)
+		digest, err := buildEmailDigest(emailRecipients, emailBodyFormat, items, metrics, now, *checkinWindow, reportOpts)
+		if err != nil {
+			logger.Errorf("error building email: %v", err)
+			exit(1)
+		}
+		if err := sendEmailDigest(smtpConfig, digest, clk.Now()); err != nil {
+			logger.Errorf("error sending email: %v", err)
+			exit(1)
+		}
+		logger.Infof("Emailed report (%d awards) to %s", len(items), strings.Join(emailRecipients, ", "))
+		enforceThresholds()
+		return
+	}
+	bundle := strings.TrimSpace(*outputDir) != ""
+	if bundle {
+		paths, err := resolveBundlePaths(strings.TrimSpace(*outputDir), *exportPath, *reportPath, *icsPath, *reportFormat, now)
+		if err == nil {
+			err = os.MkdirAll(strings.TrimSpace(*outputDir), 0o755)
+		}
+		if err != nil {
+			logger.Errorf("error preparing output dir: %v", err)
+			exit(1)
+		}
+		*exportPath, *reportPath, *icsPath = paths.Export, paths.Report, paths.ICS
+	}
+	if strings.TrimSpace(*exportPath) != "" {
+		filterMode, err := normalizeFilterMode(*exportFilter)
+		if err != nil {
+			logger.Errorf("error exporting snapshot: %v", err)
+			exit(1)
+		}
+		view, err := normalizeExportView(*exportView)
+		if err != nil {
+			logger.Errorf("error exporting snapshot: %v", err)
+			exit(1)
+		}
+		columns, err := parseExportColumns(*exportColumns)
+		if err != nil {
+			logger.Errorf("error exporting snapshot: %v", err)
+			exit(1)
+		}
+		items := sortItems(applyFilter(outputItems, filterMode), batchSort)
+		metrics := calculateSummaryMetrics(items)
+		if err := exportSnapshot(*exportPath, items, metrics, now, *checkinWindow, exportOptions{JSONLSummary: *exportJSONLSummary, View: view, Columns: columns, Risk: config.Risk}); err != nil {
+			logger.Errorf("error exporting snapshot: %v", err)
+			exit(1)
+		}
+		logger.Infof("Exported %d awards to %s", len(items), *exportPath)
+		if !bundle {
+			enforceThresholds()
+			return
+		}
+	}
+	if strings.TrimSpace(*reportPath) != "" && strings.TrimSpace(*reportSplitBy) != "" {
+		items := sortItems(applyFilter(outputItems, "all"), batchSort)
+		files, err := writeSplitReports(*reportPath, *reportFormat, *reportSplitBy, items, now, *checkinWindow, reportOpts)
+		for _, file := range files {
+			logger.Infof("Wrote %s report (%d awards) to %s", file.Key, file.Count, file.Path)
+		}
+		if err != nil {
+			logger.Errorf("error writing report: %v", err)
+			exit(1)
+		}
+		if !bundle {
+			enforceThresholds()
+			return
+		}
+	} else if strings.TrimSpace(*reportPath) != "" {
+		items := sortItems(applyFilter(outputItems, "all"), batchSort)
 		metrics := calculateSummaryMetrics(items)
-		if err := writeReport(*reportPath, *reportFormat, items, metrics, now, *checkinWindow); err != nil {
-			fmt.Println("error writing report:", err)
-			os.Exit(1)
+		if err := writeReport(*reportPath, *reportFormat, items, metrics, now, *checkinWindow, reportOpts); err != nil {
+			logger.Errorf("error writing report: %v", err)
+			exit(1)
 		}
 		if !isStdoutTarget(*reportPath) {
-			fmt.Printf("Wrote report to %s\n", *reportPath)
+			logger.Infof("Wrote report to %s", *reportPath)
 		}
+		if !bundle {
+			enforceThresholds()
+			return
+		}
+	}
+	if strings.TrimSpace(*icsPath) != "" {
+		items := sortItems(applyFilter(outputItems, "all"), "priority")
+		count, err := writeCheckinCalendar(*icsPath, items, now)
+		if err != nil {
+			logger.Errorf("error writing calendar: %v", err)
+			exit(1)
+		}
+		if !isStdoutTarget(*icsPath) {
+			logger.Infof("Wrote %d check-ins to %s", count, *icsPath)
+		}
+		enforceThresholds()
 		return
 	}
+	if anonymizeOutputs {
+		logger.Errorf("error: -anonymize applies to exports, reports, and other batch outputs, not the interactive console")
+		exit(1)
+	}
 	items := sortItems(applyFilter(baseItems, "all"), "priority")
 	metrics := calculateSummaryMetrics(items)
 	listModel := list.New(itemsToList(items), list.NewDefaultDelegate(), 0, 0)
@@ -223,62 +783,103 @@ func main() {
 		baseItems:         baseItems,
 		records:           records,
 		summary:           buildSummary(metrics, *checkinWindow),
-		detail:            buildDetail(items, 0),
-		insights:          buildInsights(items),
+		detail:            buildDetail(items, 0, 0),
+		insights:          buildInsights(items, reportOpts.OwnerOverdueSLA),
 		filterSummary:     buildRecordFilterSummary(filters),
+		saveBlocked:       saveBlockedReason(len(duplicates)),
+		filters:           filters,
+		dataPath:          *dataPath,
+		dataSource:        dataSource,
 		updatedAt:         now,
+		clock:             clk,
 		checkinWindowDays: *checkinWindow,
+		config:            config,
+		stalled:           stalled,
+		cohortDeadlines:   reportOpts.CohortDeadlines,
+		ownerOverdueSLA:   reportOpts.OwnerOverdueSLA,
 		sortMode:          "priority",
 		filterMode:        "all",
 		showInsights:      false,
+		compact:           *compact,
 	}
-
-	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
-		fmt.Println("error running program:", err)
-		os.Exit(1)
+	m.applyListDelegate()
+	if *watch || *pollInterval != 0 {
+		watcher, err := newDataWatch(dataSource, *snapshotDate, *watchInterval, *pollInterval)
+		if err != nil {
+			logger.Errorf("error: %v", err)
+			exit(1)
+		}
+		watcher.spec = *dataPath
+		watcher.lenient = *lenientCSV
+		watcher.dbURL = *dbURL
+		watcher.timeout = *dbTimeout
+		watcher.stalled = *detectStalled
+		if dataSource == "db" {
+			watcher.db, err = openDB(strings.TrimSpace(*dbURL), *dbTimeout)
+			if err != nil {
+				logger.Errorf("error connecting for -watch: %v", err)
+				exit(1)
+			}
+			defer watcher.db.Close()
+		}
+		watcher.fingerprint, _ = watcher.currentFingerprint()
+		m.watch = watcher
+	}
+	if !*noState {
+		m.statePath = defaultStatePath()
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "checkin-window" {
+				m.windowFromFlag = true
+			}
+		})
 	}
-}
 
-func loadData(path string) ([]Disbursement, error) {
-	content, err := os.ReadFile(path)
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if readStdin {
+		options = append(options, tea.WithInputTTY())
+	}
+	final, err := tea.NewProgram(m, options...).Run()
 	if err != nil {
-		return nil, err
+		logger.Errorf("error running program: %v", err)
+		exit(1)
 	}
-	var records []Disbursement
-	if err := json.Unmarshal(content, &records); err != nil {
-		return nil, err
+	if finalModel, ok := final.(model); ok && finalModel.statePath != "" {
+		if err := saveUIState(finalModel.statePath, finalModel.currentUIState()); err != nil {
+			logger.Warnf("could not save preferences: %v", err)
+		}
 	}
-	return records, nil
 }
 
-func buildItems(records []Disbursement, now time.Time, windowDays int) []awardItem {
-	items := make([]awardItem, 0, len(records))
-	checkinWindow := windowDays
-	if checkinWindow < 0 {
-		checkinWindow = 0
-	}
-	for _, record := range records {
-		pace := calculatePace(record, now)
-		check := calculateCheckin(record, now, checkinWindow)
-		risk := calculateRisk(pace, check)
-		label := renderPaceLabel(pace)
-		percent := fmt.Sprintf("%0.1f%%", pace.Percent*100)
-		gapLabel := formatSignedCurrency(pace.GapAmount)
-		checkLabel := formatCheckinBadge(check)
-		riskLabel := renderRiskLabel(risk)
-		desc := fmt.Sprintf("%s · %s disbursed · %s · %s · Gap %s · %s", record.Cohort, percent, label, checkLabel, gapLabel, riskLabel)
-		items = append(items, awardItem{
-			title: fmt.Sprintf("%s (%s)", record.Scholar, record.Owner),
-			desc:  desc,
-			data:  record,
-			pace:  pace,
-			check: check,
-			risk:  risk,
-		})
+func buildItems(records []Disbursement, now time.Time, windowDays int, config pacingConfig) []awardItem {
+	computed := pacing.BuildItems(records, now, windowDays, config)
+	items := make([]awardItem, len(computed))
+	for i, item := range computed {
+		items[i] = newAwardItem(item)
+		items[i].dateFormat = config.DateFormat
 	}
 	return items
 }
 
+func newAwardItem(item pacing.Item) awardItem {
+	record := item.Record
+	label := renderPaceLabel(item.Pace)
+	percent := fmt.Sprintf("%0.1f%%", item.Pace.Percent*100)
+	gapLabel := formatSignedCurrencyIn(item.Pace.GapAmount, record.Currency)
+	checkLabel := formatCheckinBadge(item.Checkin)
+	riskLabel := renderRiskLabel(item.Risk)
+	desc := fmt.Sprintf("%s · %s disbursed · %s · %s · Gap %s · %s", record.Cohort, percent, label, checkLabel, gapLabel, riskLabel)
+	return awardItem{
+		title:    fmt.Sprintf("%s (%s)", record.Scholar, record.Owner),
+		desc:     desc,
+		index:    item.Index,
+		data:     record,
+		pace:     item.Pace,
+		check:    item.Checkin,
+		risk:     item.Risk,
+		forecast: item.Forecast,
+	}
+}
+
 func sortItems(items []awardItem, mode string) []awardItem {
 	sorted := make([]awardItem, len(items))
 	copy(sorted, items)
@@ -308,10 +909,28 @@ func sortItems(items []awardItem, mode string) []awardItem {
 			}
 			return strings.ToLower(left.data.Scholar) < strings.ToLower(right.data.Scholar)
 		})
+	case "gap":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].pace.GapAmount != sorted[j].pace.GapAmount {
+				return sorted[i].pace.GapAmount < sorted[j].pace.GapAmount
+			}
+			return strings.ToLower(sorted[i].data.Scholar) < strings.ToLower(sorted[j].data.Scholar)
+		})
 	}
 	return sorted
 }
 
+func checkFailThresholds(metrics summaryMetrics, maxHigh, maxOverdue int) []string {
+	tripped := make([]string, 0, 2)
+	if maxHigh >= 0 && metrics.High > maxHigh {
+		tripped = append(tripped, fmt.Sprintf("%d high-risk awards (fail-on-high %d)", metrics.High, maxHigh))
+	}
+	if maxOverdue >= 0 && metrics.Overdue > maxOverdue {
+		tripped = append(tripped, fmt.Sprintf("%d overdue check-ins (fail-on-overdue %d)", metrics.Overdue, maxOverdue))
+	}
+	return tripped
+}
+
 func applyFilter(items []awardItem, mode string) []awardItem {
 	filtered := make([]awardItem, 0, len(items))
 	if mode == "all" {
@@ -331,6 +950,17 @@ func applyFilter(items []awardItem, mode string) []awardItem {
 	return filtered
 }
 
+func normalizeSortMode(mode string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(mode))
+	switch normalized {
+	case "", "priority":
+		return "priority", nil
+	case "alpha", "gap":
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown sort mode: %s (use priority, alpha, or gap)", mode)
+}
+
 func normalizeFilterMode(mode string) (string, error) {
 	normalized := strings.ToLower(strings.TrimSpace(mode))
 	if normalized == "" || normalized == "all" {
@@ -345,6 +975,69 @@ func normalizeFilterMode(mode string) (string, error) {
 	return "", fmt.Errorf("unknown filter mode: %s", mode)
 }
 
+func parseExportColumns(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	columns := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		column := strings.ToLower(strings.TrimSpace(part))
+		if column == "" {
+			continue
+		}
+		if !slices.Contains(awardExportColumns, column) {
+			return nil, fmt.Errorf("unknown export column %q (valid: %s)", part, strings.Join(awardExportColumns, ", "))
+		}
+		if slices.Contains(columns, column) {
+			return nil, fmt.Errorf("export column %q listed twice", column)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func normalizeExportView(view string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(view))
+	switch normalized {
+	case "", "awards":
+		return "awards", nil
+	case "owners", "cohorts", "summary", "attention":
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown export view: %s", view)
+}
+
+func (m *model) jumpToHighRisk(step int) {
+	visible := m.list.VisibleItems()
+	position, total := 0, 0
+	target := nextHighRiskIndex(visible, m.list.Index(), step)
+	for i, entry := range visible {
+		if item, ok := entry.(awardItem); ok && item.risk.Level == "High" {
+			total++
+			if i <= target {
+				position = total
+			}
+		}
+	}
+	if target < 0 {
+		m.statusMessage = "No high-risk awards in the current view."
+		return
+	}
+	m.list.Select(target)
+	m.statusMessage = fmt.Sprintf("High-risk award %d of %d.", position, total)
+}
+
+func nextHighRiskIndex(items []list.Item, current, step int) int {
+	count := len(items)
+	for offset := 1; offset <= count; offset++ {
+		index := ((current+step*offset)%count + count) % count
+		if item, ok := items[index].(awardItem); ok && item.risk.Level == "High" {
+			return index
+		}
+	}
+	return -1
+}
+
 func itemsToList(items []awardItem) []list.Item {
 	listItems := make([]list.Item, 0, len(items))
 	for _, item := range items {
@@ -353,26 +1046,6 @@ func itemsToList(items []awardItem) []list.Item {
 	return listItems
 }
 
-func calculatePace(record Disbursement, now time.Time) paceStatus {
-	awardDate := parseDateOrNow(record.AwardDate, now)
-	targetDate := parseDateOrNow(record.TargetDate, now)
-
-	totalDays := math.Max(1, targetDate.Sub(awardDate).Hours()/24)
-	elapsedDays := math.Max(0, now.Sub(awardDate).Hours()/24)
-	expected := clamp(elapsedDays/totalDays, 0, 1)
-	percent := clamp(record.DisbursedToDate/record.Amount, 0, 1)
-	expectedAmount := record.Amount * expected
-	gapAmount := record.DisbursedToDate - expectedAmount
-	return paceStatus{
-		Label:          paceLabel(percent - expected),
-		Delta:          percent - expected,
-		Percent:        percent,
-		Expected:       expected,
-		ExpectedAmount: expectedAmount,
-		GapAmount:      gapAmount,
-	}
-}
-
 func parseRecordFilters(ownerRaw, cohortRaw, statusRaw string) recordFilters {
 	return recordFilters{
 		owners:   parseFilterList(ownerRaw),
@@ -381,6 +1054,16 @@ func parseRecordFilters(ownerRaw, cohortRaw, statusRaw string) recordFilters {
 	}
 }
 
+func splitCommaList(raw string) []string {
+	values := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		if value := strings.TrimSpace(part); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func parseFilterList(raw string) map[string]struct{} {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
@@ -402,7 +1085,7 @@ func parseFilterList(raw string) map[string]struct{} {
 }
 
 func applyRecordFilters(records []Disbursement, filters recordFilters) []Disbursement {
-	if filters.owners == nil && filters.cohorts == nil && filters.statuses == nil {
+	if filters.owners == nil && filters.cohorts == nil && filters.statuses == nil && filters.tags == nil && filters.minAmount <= 0 && filters.maxAmount <= 0 {
 		return records
 	}
 	filtered := make([]Disbursement, 0, len(records))
@@ -437,11 +1120,44 @@ func matchesRecordFilters(record Disbursement, filters recordFilters) bool {
 			return false
 		}
 	}
+	if filters.tags != nil && !matchesTags(record.Tags, filters.tags, filters.tagMode) {
+		return false
+	}
+	if filters.minAmount > 0 && record.Amount < filters.minAmount {
+		return false
+	}
+	if filters.maxAmount > 0 && record.Amount > filters.maxAmount {
+		return false
+	}
 	return true
 }
 
+func applyItemFilters(items []awardItem, filters recordFilters) []awardItem {
+	if filters.paces == nil && filters.risks == nil && filters.minDaysOverdue <= 0 {
+		return items
+	}
+	filtered := make([]awardItem, 0, len(items))
+	for _, item := range items {
+		if filters.paces != nil {
+			if _, ok := filters.paces[strings.ToLower(item.pace.Label)]; !ok {
+				continue
+			}
+		}
+		if filters.risks != nil {
+			if _, ok := filters.risks[strings.ToLower(item.risk.Level)]; !ok {
+				continue
+			}
+		}
+		if filters.minDaysOverdue > 0 && (item.check.Label != "Overdue" || -item.check.Days < filters.minDaysOverdue) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
 func buildRecordFilterSummary(filters recordFilters) string {
-	parts := make([]string, 0, 3)
+	parts := make([]string, 0, 6)
 	if len(filters.owners) > 0 {
 		parts = append(parts, "owner="+strings.Join(sortedKeys(filters.owners), ", "))
 	}
@@ -451,6 +1167,30 @@ func buildRecordFilterSummary(filters recordFilters) string {
 	if len(filters.statuses) > 0 {
 		parts = append(parts, "status="+strings.Join(sortedKeys(filters.statuses), ", "))
 	}
+	if len(filters.tags) > 0 {
+		label := "tag="
+		if filters.tagMode == "all" && len(filters.tags) > 1 {
+			label = "tag(all)="
+		}
+		parts = append(parts, label+strings.Join(sortedKeys(filters.tags), ", "))
+	}
+	switch {
+	case filters.minAmount > 0 && filters.maxAmount > 0:
+		parts = append(parts, fmt.Sprintf("amount=$%0.0f–$%0.0f", filters.minAmount, filters.maxAmount))
+	case filters.minAmount > 0:
+		parts = append(parts, fmt.Sprintf("amount>=$%0.0f", filters.minAmount))
+	case filters.maxAmount > 0:
+		parts = append(parts, fmt.Sprintf("amount<=$%0.0f", filters.maxAmount))
+	}
+	if len(filters.paces) > 0 {
+		parts = append(parts, "pace="+strings.Join(sortedKeys(filters.paces), ", "))
+	}
+	if len(filters.risks) > 0 {
+		parts = append(parts, "risk="+strings.Join(sortedKeys(filters.risks), ", "))
+	}
+	if filters.minDaysOverdue > 0 {
+		parts = append(parts, fmt.Sprintf("overdue>=%dd", filters.minDaysOverdue))
+	}
 	if len(parts) == 0 {
 		return ""
 	}
@@ -466,36 +1206,6 @@ func sortedKeys(values map[string]struct{}) []string {
 	return keys
 }
 
-func calculateCheckin(record Disbursement, now time.Time, windowDays int) checkinStatus {
-	if record.NextCheckin == "" {
-		return checkinStatus{Label: "Unscheduled"}
-	}
-	checkDate, ok := parseDateOptional(record.NextCheckin)
-	if !ok {
-		return checkinStatus{Label: "Unscheduled"}
-	}
-	nowDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	checkDate = time.Date(checkDate.Year(), checkDate.Month(), checkDate.Day(), 0, 0, 0, 0, checkDate.Location())
-	daysUntil := int(math.Round(checkDate.Sub(nowDate).Hours() / 24))
-	label := "Scheduled"
-	if daysUntil < 0 {
-		label = "Overdue"
-	} else if daysUntil <= windowDays {
-		label = "Due Soon"
-	}
-	return checkinStatus{Label: label, Days: daysUntil, Date: checkDate}
-}
-
-func paceLabel(delta float64) string {
-	if delta >= 0.1 {
-		return "Ahead"
-	}
-	if delta <= -0.1 {
-		return "Behind"
-	}
-	return "On Track"
-}
-
 func checkinRank(label string) int {
 	switch label {
 	case "Overdue":
@@ -504,6 +1214,8 @@ func checkinRank(label string) int {
 		return 1
 	case "Scheduled":
 		return 2
+	case "Completed":
+		return 4
 	default:
 		return 3
 	}
@@ -513,32 +1225,38 @@ func paceRank(label string) int {
 	switch label {
 	case "Behind":
 		return 0
-	case "On Track":
+	case "Unknown", "No Amount":
 		return 1
-	default:
+	case "On Track":
 		return 2
+	case "Completed":
+		return 4
+	default:
+		return 3
 	}
 }
 
 func renderPaceLabel(p paceStatus) string {
 	switch p.Label {
 	case "Ahead":
-		return statusAhead.Render("Ahead")
+		return statusAhead.Render(markerAhead + "Ahead")
 	case "Behind":
-		return statusBehind.Render("Behind")
+		return statusBehind.Render(markerBehind + "Behind")
+	case "Unknown", "No Amount", "Completed":
+		return subtle.Render(p.Label)
 	default:
-		return statusOn.Render("On Track")
+		return statusOn.Render(markerOn + "On Track")
 	}
 }
 
 func renderCheckinLabel(c checkinStatus) string {
 	switch c.Label {
 	case "Overdue":
-		return statusBehind.Render("Overdue")
+		return statusBehind.Render(markerBehind + "Overdue")
 	case "Due Soon":
-		return statusOn.Render("Due Soon")
-	case "Scheduled":
-		return subtle.Render("Scheduled")
+		return statusOn.Render(markerOn + "Due Soon")
+	case "Scheduled", "Completed":
+		return subtle.Render(c.Label)
 	default:
 		return subtle.Render("Unscheduled")
 	}
@@ -547,9 +1265,9 @@ func renderCheckinLabel(c checkinStatus) string {
 func renderRiskLabel(r riskStatus) string {
 	switch r.Level {
 	case "High":
-		return statusBehind.Render("Risk: High")
+		return statusBehind.Render(markerBehind + "Risk: High")
 	case "Medium":
-		return statusOn.Render("Risk: Medium")
+		return statusOn.Render(markerOn + "Risk: Medium")
 	default:
 		return subtle.Render("Risk: Low")
 	}
@@ -573,68 +1291,19 @@ func formatCheckinBadge(c checkinStatus) string {
 }
 
 func formatSignedCurrency(value float64) string {
-	sign := "-"
-	if value >= 0 {
-		sign = "+"
-	}
-	return fmt.Sprintf("%s$%0.0f", sign, math.Abs(value))
-}
-
-func calculateRisk(pace paceStatus, check checkinStatus) riskStatus {
-	score := 0
-	flags := make([]string, 0, 3)
-	if pace.Label == "Behind" {
-		score += 2
-		flags = append(flags, "Behind pace")
-	}
-	if check.Label == "Overdue" {
-		score += 2
-		flags = append(flags, "Check-in overdue")
-	}
-	if check.Label == "Due Soon" {
-		score++
-		flags = append(flags, "Check-in due soon")
-	}
-	if check.Label == "Unscheduled" {
-		score++
-		flags = append(flags, "Check-in unscheduled")
-	}
-	if pace.Label == "Ahead" {
-		score--
-	}
-	level := "Low"
-	if score >= 3 {
-		level = "High"
-	} else if score >= 2 {
-		level = "Medium"
-	}
-	return riskStatus{Level: level, Flags: flags, Score: score}
-}
-
-func parseDateOrNow(value string, fallback time.Time) time.Time {
-	parsed, err := time.Parse("2006-01-02", value)
-	if err != nil {
-		return fallback
-	}
-	return parsed
+	return formatSignedCurrencyIn(value, defaultCurrency)
 }
 
-func parseDateOptional(value string) (time.Time, bool) {
-	parsed, err := time.Parse("2006-01-02", value)
+func loadRiskConfig(path string) (riskConfig, error) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return time.Time{}, false
-	}
-	return parsed, true
-}
-
-func clamp(value, min, max float64) float64 {
-	if value < min {
-		return min
+		return riskConfig{}, err
 	}
-	if value > max {
-		return max
+	config := pacing.DefaultRiskConfig()
+	if err := json.Unmarshal(content, &config); err != nil {
+		return riskConfig{}, err
 	}
-	return value
+	return config, nil
 }
 
 func calculateSummaryMetrics(items []awardItem) summaryMetrics {
@@ -644,18 +1313,28 @@ func calculateSummaryMetrics(items []awardItem) summaryMetrics {
 	}
 	for _, item := range items {
 		record := item.data
-		metrics.TotalAwarded += record.Amount
-		metrics.TotalDisbursed += record.DisbursedToDate
-		metrics.TotalExpected += item.pace.ExpectedAmount
-		metrics.TotalGap += item.pace.GapAmount
+		if hasAmount(record) {
+			metrics.TotalAwarded += record.Amount
+			metrics.TotalDisbursed += record.DisbursedToDate
+			metrics.TotalExpected += item.pace.ExpectedAmount
+			metrics.TotalGap += item.pace.GapAmount
+			metrics.TotalRemaining += math.Max(0, record.Amount-record.DisbursedToDate)
+		}
 		switch item.pace.Label {
+		case "No Amount":
+			metrics.NoAmount++
+		case "Completed":
+			metrics.Completed++
 		case "Ahead":
 			metrics.Ahead++
 		case "Behind":
 			metrics.Behind++
+		case "Unknown":
+			metrics.Unknown++
 		default:
 			metrics.OnTrack++
 		}
+		metrics.Warnings += len(recordWarnings(record, item.dateConfig()))
 		if item.check.Label == "Overdue" {
 			metrics.Overdue++
 		}
@@ -671,15 +1350,33 @@ func calculateSummaryMetrics(items []awardItem) summaryMetrics {
 			metrics.Low++
 		}
 		if !item.check.Date.IsZero() && item.check.Label != "Overdue" {
-			metrics.Upcoming = append(metrics.Upcoming, item.check.Date.Format("Jan 2")+" · "+record.Scholar)
+			metrics.Upcoming = append(metrics.Upcoming, formatShortDate(item.check.Date)+" · "+record.Scholar)
 		}
 	}
 	if metrics.TotalAwarded > 0 {
 		metrics.Completion = metrics.TotalDisbursed / metrics.TotalAwarded
 	}
+	metrics.Currencies = buildCurrencyTotals(items)
 	return metrics
 }
 
+// hasAmount reports whether an award counts toward money totals and
+// completion; zero-amount awards are tallied only as NoAmount.
+func hasAmount(record Disbursement) bool {
+	return record.Amount > 0
+}
+
+func formatPaceMix(metrics summaryMetrics) string {
+	mix := fmt.Sprintf("Ahead %d · On track %d · Behind %d · Unknown %d", metrics.Ahead, metrics.OnTrack, metrics.Behind, metrics.Unknown)
+	if metrics.NoAmount > 0 {
+		mix += fmt.Sprintf(" · No amount %d", metrics.NoAmount)
+	}
+	if metrics.Completed > 0 {
+		mix += fmt.Sprintf(" · Completed %d", metrics.Completed)
+	}
+	return mix
+}
+
 func buildSummary(metrics summaryMetrics, dueSoonDays int) string {
 	if metrics.Count == 0 {
 		return "No records loaded."
@@ -691,15 +1388,31 @@ func buildSummary(metrics summaryMetrics, dueSoonDays int) string {
 	} else if len(preview) > 64 {
 		preview = preview[:64] + "…"
 	}
-	return fmt.Sprintf("$%0.0f awarded · $%0.0f disbursed (%0.1f%%) · Expected $%0.0f · Gap %s · Pace %d ahead / %d on / %d behind · Risk %d high / %d med / %d low · %d overdue · %d due in %d days · Next: %s",
-		metrics.TotalAwarded,
-		metrics.TotalDisbursed,
+	totals := fmt.Sprintf("%s awarded · %s disbursed (%0.1f%%) · %s remaining · Expected %s · Gap %s",
+		formatCurrency(metrics.TotalAwarded, metrics.currency()),
+		formatCurrency(metrics.TotalDisbursed, metrics.currency()),
 		metrics.Completion*100,
-		metrics.TotalExpected,
-		formatSignedCurrency(metrics.TotalGap),
+		formatCurrency(metrics.TotalRemaining, metrics.currency()),
+		formatCurrency(metrics.TotalExpected, metrics.currency()),
+		formatSignedCurrencyIn(metrics.TotalGap, metrics.currency()),
+	)
+	if len(metrics.Currencies) > 1 {
+		totals = formatCurrencyTotals(metrics.Currencies) + " (mixed currencies, not combined)"
+	}
+	noAmount := ""
+	if metrics.NoAmount > 0 {
+		noAmount = fmt.Sprintf(" / %d no amount", metrics.NoAmount)
+	}
+	if metrics.Completed > 0 {
+		noAmount += fmt.Sprintf(" / %d completed", metrics.Completed)
+	}
+	return fmt.Sprintf("%s · Pace %d ahead / %d on / %d behind / %d unknown%s · Risk %d high / %d med / %d low · %d overdue · %d due in %d days · Next: %s",
+		totals,
 		metrics.Ahead,
 		metrics.OnTrack,
 		metrics.Behind,
+		metrics.Unknown,
+		noAmount,
 		metrics.High,
 		metrics.Medium,
 		metrics.Low,
@@ -711,21 +1424,27 @@ func buildSummary(metrics summaryMetrics, dueSoonDays int) string {
 }
 
 type exportSummary struct {
-	Count          int      `json:"count"`
-	TotalAwarded   float64  `json:"total_awarded"`
-	TotalDisbursed float64  `json:"total_disbursed"`
-	TotalExpected  float64  `json:"total_expected"`
-	TotalGap       float64  `json:"total_gap"`
-	Completion     float64  `json:"completion"`
-	Ahead          int      `json:"ahead"`
-	OnTrack        int      `json:"on_track"`
-	Behind         int      `json:"behind"`
-	Overdue        int      `json:"overdue"`
-	DueSoon        int      `json:"due_soon"`
-	High           int      `json:"high"`
-	Medium         int      `json:"medium"`
-	Low            int      `json:"low"`
-	Upcoming       []string `json:"upcoming"`
+	Count          int             `json:"count"`
+	TotalAwarded   *float64        `json:"total_awarded"`
+	TotalDisbursed *float64        `json:"total_disbursed"`
+	TotalExpected  *float64        `json:"total_expected"`
+	TotalGap       *float64        `json:"total_gap"`
+	TotalRemaining *float64        `json:"total_remaining"`
+	Completion     *float64        `json:"completion"`
+	Ahead          int             `json:"ahead"`
+	OnTrack        int             `json:"on_track"`
+	Behind         int             `json:"behind"`
+	Unknown        int             `json:"unknown"`
+	NoAmount       int             `json:"no_amount"`
+	Completed      int             `json:"completed"`
+	Warnings       int             `json:"warnings"`
+	Currencies     []currencyTotal `json:"currencies,omitempty"`
+	Overdue        int             `json:"overdue"`
+	DueSoon        int             `json:"due_soon"`
+	High           int             `json:"high"`
+	Medium         int             `json:"medium"`
+	Low            int             `json:"low"`
+	Upcoming       []string        `json:"upcoming"`
 }
 
 type exportItem struct {
@@ -735,6 +1454,7 @@ type exportItem struct {
 	Status          string   `json:"status"`
 	Amount          float64  `json:"amount"`
 	DisbursedToDate float64  `json:"disbursed_to_date"`
+	Currency        string   `json:"currency"`
 	AwardDate       string   `json:"award_date"`
 	TargetDate      string   `json:"target_date"`
 	NextCheckin     string   `json:"next_checkin"`
@@ -742,6 +1462,7 @@ type exportItem struct {
 	PacePercent     float64  `json:"pace_percent"`
 	PaceDelta       float64  `json:"pace_delta"`
 	ExpectedPercent float64  `json:"expected_percent"`
+	TimeElapsed     float64  `json:"time_elapsed_percent"`
 	ExpectedAmount  float64  `json:"expected_amount"`
 	GapAmount       float64  `json:"gap_amount"`
 	CheckinLabel    string   `json:"checkin_label"`
@@ -750,6 +1471,7 @@ type exportItem struct {
 	RiskScore       int      `json:"risk_score"`
 	RiskFlags       []string `json:"risk_flags,omitempty"`
 	Notes           string   `json:"notes"`
+	Warnings        []string `json:"warnings,omitempty"`
 }
 
 type exportSnapshotPayload struct {
@@ -759,30 +1481,86 @@ type exportSnapshotPayload struct {
 	Items             []exportItem  `json:"items"`
 }
 
-type reportPayload struct {
-	GeneratedAt       string          `json:"generated_at"`
-	CheckinWindowDays int             `json:"checkin_window_days"`
-	Summary           exportSummary   `json:"summary"`
-	Owners            []ownerSummary  `json:"owners"`
-	Cohorts           []cohortSummary `json:"cohorts"`
-	Statuses          []statusSummary `json:"statuses"`
+type exportJSONLSummary struct {
+	Type              string        `json:"type"`
+	GeneratedAt       string        `json:"generated_at"`
+	CheckinWindowDays int           `json:"checkin_window_days"`
+	Summary           exportSummary `json:"summary"`
+}
+
+type exportOptions struct {
+	JSONLSummary bool
+	View         string
+	Columns      []string
+	Risk         pacing.RiskConfig
+}
+
+type reportPayload struct {
+	GeneratedAt       string             `json:"generated_at"`
+	CheckinWindowDays int                `json:"checkin_window_days"`
+	Summary           exportSummary      `json:"summary"`
+	Owners            []ownerSummary     `json:"owners"`
+	Cohorts           []cohortSummary    `json:"cohorts"`
+	Statuses          []statusSummary    `json:"statuses"`
+	Tags              []tagSummary       `json:"tags,omitempty"`
+	OverdueBuckets    overdueBuckets     `json:"overdue_buckets"`
+	Stalled           []stalledAward     `json:"stalled,omitempty"`
+	CohortDeadlines   []cohortDeadline   `json:"cohort_deadlines,omitempty"`
+	CohortWindows     []cohortWindow     `json:"cohort_checkin_windows,omitempty"`
+	OwnerOverdueSLA   *int               `json:"owner_overdue_sla,omitempty"`
+	OwnerSLABreaches  []ownerSLABreach   `json:"owner_sla_breaches,omitempty"`
+	Forecast          *pacing.Forecast   `json:"forecast"`
+	CurrencyForecasts []currencyForecast `json:"forecast_by_currency,omitempty"`
+}
+
+type reportSummaryPayload struct {
+	GeneratedAt       string        `json:"generated_at"`
+	CheckinWindowDays int           `json:"checkin_window_days"`
+	Summary           exportSummary `json:"summary"`
+}
+
+// reportOptions shapes text, Markdown, and JSON reports. Top caps the owner
+// and cohort sections (0 keeps the defaults); SummaryOnly drops every section
+// after the summary block; CohortDeadlines adds the cohort deadline rollup;
+// CohortWindows lists per-cohort check-in windows beside the default.
+type reportOptions struct {
+	Top             int
+	SummaryOnly     bool
+	CohortDeadlines map[string]time.Time
+	CohortWindows   []cohortWindow
+	OwnerOverdueSLA int
+}
+
+type overdueBuckets struct {
+	OneToSeven    int `json:"days_1_7"`
+	EightToThirty int `json:"days_8_30"`
+	ThirtyOneUp   int `json:"days_31_plus"`
 }
 
 type ownerSummary struct {
-	Owner    string
-	Awards   int
-	High     int
-	Overdue  int
-	DueSoon  int
-	GapTotal float64
+	Owner          string
+	Awards         int
+	High           int
+	Overdue        int
+	DueSoon        int
+	GapTotal       float64
+	TotalAwarded   float64
+	TotalDisbursed float64
+	// currency is the owner's one award currency, or "" when the owner's
+	// awards mix currencies and the dollar totals cannot be shown.
+	currency string
 }
 
 type cohortSummary struct {
-	Cohort     string
-	Awards     int
-	Behind     int
-	GapTotal   float64
-	Completion float64
+	Cohort           string
+	Awards           int
+	NoAmount         int
+	Behind           int
+	GapTotal         float64
+	Completion       float64
+	DollarCompletion float64
+	awarded          float64
+	disbursed        float64
 }
 
 type statusSummary struct {
@@ -790,90 +1568,182 @@ type statusSummary struct {
 	Count  int
 }
 
-func exportSnapshot(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) error {
+func exportSnapshot(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options exportOptions) error {
+	if isGzipPath(path) {
+		return exportSnapshotGzip(path, items, metrics, generatedAt, checkinWindow, options)
+	}
 	ext := strings.ToLower(filepath.Ext(path))
 	if ext == "" {
 		ext = ".csv"
 		path = path + ext
 	}
+	if len(options.Columns) > 0 && (ext != ".csv" || (options.View != "" && options.View != "awards")) {
+		return fmt.Errorf("export columns apply only to .csv award exports")
+	}
+	if options.View == "summary" {
+		if ext != ".json" {
+			return fmt.Errorf("export view summary requires a .json export")
+		}
+		content, err := buildSummaryJSON(metrics, generatedAt, checkinWindow)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, content, 0o644)
+	}
+	if options.View == "attention" {
+		if ext != ".json" {
+			return fmt.Errorf("export view attention requires a .json export")
+		}
+		return exportAttentionJSON(path, items, options.Risk)
+	}
+	if options.View != "" && options.View != "awards" {
+		if ext != ".csv" {
+			return fmt.Errorf("export view %s requires a .csv export", options.View)
+		}
+		if options.View == "owners" {
+			return exportOwnersCSV(path, buildOwnerSummaries(items))
+		}
+		return exportCohortsCSV(path, buildCohortSummaries(items))
+	}
 	if ext == ".json" {
 		return exportSnapshotJSON(path, items, metrics, generatedAt, checkinWindow)
 	}
 	if ext == ".csv" {
-		return exportSnapshotCSV(path, items, metrics, generatedAt, checkinWindow)
+		return exportSnapshotCSV(path, items, metrics, generatedAt, checkinWindow, options.Columns)
+	}
+	if ext == ".jsonl" || ext == ".ndjson" {
+		return exportSnapshotJSONL(path, items, metrics, generatedAt, checkinWindow, options)
+	}
+	if ext == ".html" || ext == ".htm" {
+		return exportSnapshotHTML(path, items, metrics, generatedAt, checkinWindow)
 	}
 	return fmt.Errorf("unsupported export format: %s", ext)
 }
 
 func exportSnapshotJSON(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) error {
+	content, err := buildSnapshotJSON(items, metrics, generatedAt, checkinWindow)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+func buildSnapshotJSON(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) ([]byte, error) {
 	payload := exportSnapshotPayload{
 		GeneratedAt:       generatedAt.Format(time.RFC3339),
 		CheckinWindowDays: checkinWindow,
-		Summary: exportSummary{
-			Count:          metrics.Count,
-			TotalAwarded:   metrics.TotalAwarded,
-			TotalDisbursed: metrics.TotalDisbursed,
-			TotalExpected:  metrics.TotalExpected,
-			TotalGap:       metrics.TotalGap,
-			Completion:     metrics.Completion,
-			Ahead:          metrics.Ahead,
-			OnTrack:        metrics.OnTrack,
-			Behind:         metrics.Behind,
-			Overdue:        metrics.Overdue,
-			DueSoon:        metrics.DueSoon,
-			High:           metrics.High,
-			Medium:         metrics.Medium,
-			Low:            metrics.Low,
-			Upcoming:       metrics.Upcoming,
-		},
-		Items: make([]exportItem, 0, len(items)),
+		Summary:           buildExportSummary(metrics),
+		Items:             make([]exportItem, 0, len(items)),
 	}
 	for _, item := range items {
-		record := item.data
-		checkinDays := (*int)(nil)
-		if item.check.Label != "Unscheduled" {
-			days := item.check.Days
-			checkinDays = &days
-		}
-		payload.Items = append(payload.Items, exportItem{
-			Scholar:         record.Scholar,
-			Cohort:          record.Cohort,
-			Owner:           record.Owner,
-			Status:          record.Status,
-			Amount:          record.Amount,
-			DisbursedToDate: record.DisbursedToDate,
-			AwardDate:       record.AwardDate,
-			TargetDate:      record.TargetDate,
-			NextCheckin:     record.NextCheckin,
-			PaceLabel:       item.pace.Label,
-			PacePercent:     item.pace.Percent,
-			PaceDelta:       item.pace.Delta,
-			ExpectedPercent: item.pace.Expected,
-			ExpectedAmount:  item.pace.ExpectedAmount,
-			GapAmount:       item.pace.GapAmount,
-			CheckinLabel:    item.check.Label,
-			CheckinDays:     checkinDays,
-			RiskLevel:       item.risk.Level,
-			RiskScore:       item.risk.Score,
-			RiskFlags:       item.risk.Flags,
-			Notes:           record.Notes,
-		})
+		payload.Items = append(payload.Items, buildExportItem(item))
+	}
+	return json.MarshalIndent(payload, "", "  ")
+}
+
+func buildExportSummary(metrics summaryMetrics) exportSummary {
+	return exportSummary{
+		Count:          metrics.Count,
+		TotalAwarded:   combinedTotal(metrics, metrics.TotalAwarded),
+		TotalDisbursed: combinedTotal(metrics, metrics.TotalDisbursed),
+		TotalExpected:  combinedTotal(metrics, metrics.TotalExpected),
+		TotalGap:       combinedTotal(metrics, metrics.TotalGap),
+		TotalRemaining: combinedTotal(metrics, metrics.TotalRemaining),
+		Completion:     combinedTotal(metrics, metrics.Completion),
+		Ahead:          metrics.Ahead,
+		OnTrack:        metrics.OnTrack,
+		Behind:         metrics.Behind,
+		Unknown:        metrics.Unknown,
+		NoAmount:       metrics.NoAmount,
+		Completed:      metrics.Completed,
+		Warnings:       metrics.Warnings,
+		Currencies:     mixedCurrencyTotals(metrics),
+		Overdue:        metrics.Overdue,
+		DueSoon:        metrics.DueSoon,
+		High:           metrics.High,
+		Medium:         metrics.Medium,
+		Low:            metrics.Low,
+		Upcoming:       metrics.Upcoming,
 	}
-	content, err := json.MarshalIndent(payload, "", "  ")
+}
+
+func buildExportItem(item awardItem) exportItem {
+	record := item.data
+	checkinDays := (*int)(nil)
+	if item.check.Label != "Unscheduled" {
+		days := item.check.Days
+		checkinDays = &days
+	}
+	return exportItem{
+		Scholar:         record.Scholar,
+		Cohort:          record.Cohort,
+		Owner:           record.Owner,
+		Status:          record.Status,
+		Amount:          record.Amount,
+		DisbursedToDate: record.DisbursedToDate,
+		Currency:        normalizeCurrency(record.Currency),
+		AwardDate:       record.AwardDate,
+		TargetDate:      record.TargetDate,
+		NextCheckin:     record.NextCheckin,
+		PaceLabel:       item.pace.Label,
+		PacePercent:     item.pace.Percent,
+		PaceDelta:       item.pace.Delta,
+		ExpectedPercent: item.pace.Expected,
+		TimeElapsed:     item.pace.Elapsed,
+		ExpectedAmount:  item.pace.ExpectedAmount,
+		GapAmount:       item.pace.GapAmount,
+		CheckinLabel:    item.check.Label,
+		CheckinDays:     checkinDays,
+		RiskLevel:       item.risk.Level,
+		RiskScore:       item.risk.Score,
+		RiskFlags:       item.risk.Flags,
+		Notes:           record.Notes,
+		Warnings:        recordWarnings(record, item.dateConfig()),
+	}
+}
+
+func exportSnapshotJSONL(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options exportOptions) error {
+	file, err := os.Create(path)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, content, 0o644)
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	if options.JSONLSummary {
+		if err := encoder.Encode(exportJSONLSummary{
+			Type:              "summary",
+			GeneratedAt:       generatedAt.Format(time.RFC3339),
+			CheckinWindowDays: checkinWindow,
+			Summary:           buildExportSummary(metrics),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if err := encoder.Encode(buildExportItem(item)); err != nil {
+			return err
+		}
+	}
+	return file.Close()
 }
 
-func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) error {
+func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, columns []string) error {
 	file, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
+	if err := writeSnapshotCSV(file, items, metrics, generatedAt, checkinWindow, columns); err != nil {
+		return err
+	}
+	return file.Close()
+}
 
-	writer := csv.NewWriter(file)
+// writeSnapshotCSV writes the summary block, then the award table restricted to
+// columns in their given order; nil columns means every award column.
+func writeSnapshotCSV(w io.Writer, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, columns []string) error {
+	writer := csv.NewWriter(w)
 	if err := writer.Write([]string{
 		"generated_at",
 		"checkin_window_days",
@@ -882,10 +1752,14 @@ func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, g
 		"summary_total_disbursed",
 		"summary_total_expected",
 		"summary_total_gap",
+		"summary_total_remaining",
 		"summary_completion",
 		"summary_ahead",
 		"summary_on_track",
 		"summary_behind",
+		"summary_unknown",
+		"summary_no_amount",
+		"summary_completed",
 		"summary_overdue",
 		"summary_due_soon",
 		"summary_high",
@@ -898,14 +1772,18 @@ func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, g
 		generatedAt.Format(time.RFC3339),
 		fmt.Sprintf("%d", checkinWindow),
 		fmt.Sprintf("%d", metrics.Count),
-		fmt.Sprintf("%0.2f", metrics.TotalAwarded),
-		fmt.Sprintf("%0.2f", metrics.TotalDisbursed),
-		fmt.Sprintf("%0.2f", metrics.TotalExpected),
-		fmt.Sprintf("%0.2f", metrics.TotalGap),
-		fmt.Sprintf("%0.4f", metrics.Completion),
+		combinedTotalCell(metrics, "%0.2f", metrics.TotalAwarded),
+		combinedTotalCell(metrics, "%0.2f", metrics.TotalDisbursed),
+		combinedTotalCell(metrics, "%0.2f", metrics.TotalExpected),
+		combinedTotalCell(metrics, "%0.2f", metrics.TotalGap),
+		combinedTotalCell(metrics, "%0.2f", metrics.TotalRemaining),
+		combinedTotalCell(metrics, "%0.4f", metrics.Completion),
 		fmt.Sprintf("%d", metrics.Ahead),
 		fmt.Sprintf("%d", metrics.OnTrack),
 		fmt.Sprintf("%d", metrics.Behind),
+		fmt.Sprintf("%d", metrics.Unknown),
+		fmt.Sprintf("%d", metrics.NoAmount),
+		fmt.Sprintf("%d", metrics.Completed),
 		fmt.Sprintf("%d", metrics.Overdue),
 		fmt.Sprintf("%d", metrics.DueSoon),
 		fmt.Sprintf("%d", metrics.High),
@@ -915,61 +1793,24 @@ func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, g
 		return err
 	}
 
-	if err := writer.Write([]string{
-		"scholar",
-		"cohort",
-		"owner",
-		"status",
-		"amount",
-		"disbursed_to_date",
-		"award_date",
-		"target_date",
-		"next_checkin",
-		"pace_label",
-		"pace_percent",
-		"pace_delta",
-		"expected_percent",
-		"expected_amount",
-		"gap_amount",
-		"checkin_label",
-		"checkin_days",
-		"risk_level",
-		"risk_score",
-		"risk_flags",
-		"notes",
-	}); err != nil {
+	if len(columns) == 0 {
+		columns = awardExportColumns
+	}
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		positions[i] = slices.Index(awardExportColumns, column)
+	}
+	if err := writer.Write(columns); err != nil {
 		return err
 	}
 
 	for _, item := range items {
-		record := item.data
-		checkinDays := ""
-		if item.check.Label != "Unscheduled" {
-			checkinDays = fmt.Sprintf("%d", item.check.Days)
-		}
-		if err := writer.Write([]string{
-			record.Scholar,
-			record.Cohort,
-			record.Owner,
-			record.Status,
-			fmt.Sprintf("%0.2f", record.Amount),
-			fmt.Sprintf("%0.2f", record.DisbursedToDate),
-			record.AwardDate,
-			record.TargetDate,
-			record.NextCheckin,
-			item.pace.Label,
-			fmt.Sprintf("%0.4f", item.pace.Percent),
-			fmt.Sprintf("%0.4f", item.pace.Delta),
-			fmt.Sprintf("%0.4f", item.pace.Expected),
-			fmt.Sprintf("%0.2f", item.pace.ExpectedAmount),
-			fmt.Sprintf("%0.2f", item.pace.GapAmount),
-			item.check.Label,
-			checkinDays,
-			item.risk.Level,
-			fmt.Sprintf("%d", item.risk.Score),
-			strings.Join(item.risk.Flags, "; "),
-			record.Notes,
-		}); err != nil {
+		row := awardExportRow(item)
+		selected := make([]string, len(positions))
+		for i, position := range positions {
+			selected[i] = row[position]
+		}
+		if err := writer.Write(selected); err != nil {
 			return err
 		}
 	}
@@ -977,23 +1818,160 @@ func exportSnapshotCSV(path string, items []awardItem, metrics summaryMetrics, g
 	return writer.Error()
 }
 
-func writeReport(path, format string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) error {
+func exportOwnersCSV(path string, summaries []ownerSummary) error {
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, []string{
+			summary.Owner,
+			fmt.Sprintf("%d", summary.Awards),
+			fmt.Sprintf("%d", summary.High),
+			fmt.Sprintf("%d", summary.Overdue),
+			fmt.Sprintf("%d", summary.DueSoon),
+			fmt.Sprintf("%0.2f", summary.GapTotal),
+			fmt.Sprintf("%0.2f", summary.TotalAwarded),
+			fmt.Sprintf("%0.2f", summary.TotalDisbursed),
+		})
+	}
+	return writeRollupCSV(path, []string{"owner", "awards", "high", "overdue", "due_soon", "gap_total", "total_awarded", "total_disbursed"}, rows)
+}
+
+func exportCohortsCSV(path string, summaries []cohortSummary) error {
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, []string{
+			summary.Cohort,
+			fmt.Sprintf("%d", summary.Awards),
+			fmt.Sprintf("%d", summary.Behind),
+			fmt.Sprintf("%0.2f", summary.GapTotal),
+			fmt.Sprintf("%0.4f", summary.Completion),
+			fmt.Sprintf("%0.4f", summary.DollarCompletion),
+			fmt.Sprintf("%0.2f", summary.awarded),
+			fmt.Sprintf("%0.2f", summary.disbursed),
+		})
+	}
+	return writeRollupCSV(path, []string{"cohort", "awards", "behind", "gap_total", "completion", "dollar_completion", "total_awarded", "total_disbursed"}, rows)
+}
+
+func writeRollupCSV(path string, header []string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+var awardExportColumns = []string{
+	"scholar",
+	"cohort",
+	"owner",
+	"status",
+	"amount",
+	"disbursed_to_date",
+	"currency",
+	"award_date",
+	"target_date",
+	"next_checkin",
+	"pace_label",
+	"pace_percent",
+	"pace_delta",
+	"expected_percent",
+	"time_elapsed_percent",
+	"expected_amount",
+	"gap_amount",
+	"checkin_label",
+	"checkin_days",
+	"risk_level",
+	"risk_score",
+	"risk_flags",
+	"notes",
+}
+
+func awardExportRow(item awardItem) []string {
+	record := item.data
+	checkinDays := ""
+	if item.check.Label != "Unscheduled" {
+		checkinDays = fmt.Sprintf("%d", item.check.Days)
+	}
+	return []string{
+		record.Scholar,
+		record.Cohort,
+		record.Owner,
+		record.Status,
+		fmt.Sprintf("%0.2f", record.Amount),
+		fmt.Sprintf("%0.2f", record.DisbursedToDate),
+		normalizeCurrency(record.Currency),
+		record.AwardDate,
+		record.TargetDate,
+		record.NextCheckin,
+		item.pace.Label,
+		fmt.Sprintf("%0.4f", item.pace.Percent),
+		fmt.Sprintf("%0.4f", item.pace.Delta),
+		fmt.Sprintf("%0.4f", item.pace.Expected),
+		fmt.Sprintf("%0.4f", item.pace.Elapsed),
+		fmt.Sprintf("%0.2f", item.pace.ExpectedAmount),
+		fmt.Sprintf("%0.2f", item.pace.GapAmount),
+		item.check.Label,
+		checkinDays,
+		item.risk.Level,
+		fmt.Sprintf("%d", item.risk.Score),
+		strings.Join(item.risk.Flags, "; "),
+		record.Notes,
+	}
+}
+
+func writeReport(path, format string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) error {
 	format, err := normalizeReportFormat(path, format)
 	if err != nil {
 		return err
 	}
 	if format == "json" {
-		payload := buildReportPayload(items, metrics, generatedAt, checkinWindow)
-		content, err := json.MarshalIndent(payload, "", "  ")
+		content, err := buildReportJSON(items, metrics, generatedAt, checkinWindow, options)
 		if err != nil {
 			return err
 		}
 		return writeReportOutput(path, content)
 	}
-	content := []byte(buildReportText(items, metrics, generatedAt, checkinWindow))
+	if format == "markdown" {
+		return writeReportOutput(path, []byte(buildReportMarkdown(items, metrics, generatedAt, checkinWindow, options)))
+	}
+	if format == "table" {
+		return writeReportOutput(path, []byte(buildReportTable(items, generatedAt, checkinWindow, isStdoutTarget(path))))
+	}
+	content := []byte(buildReportText(items, metrics, generatedAt, checkinWindow, options))
 	return writeReportOutput(path, content)
 }
 
+func buildSummaryJSON(metrics summaryMetrics, generatedAt time.Time, checkinWindow int) ([]byte, error) {
+	return json.MarshalIndent(reportSummaryPayload{
+		GeneratedAt:       generatedAt.Format(time.RFC3339),
+		CheckinWindowDays: checkinWindow,
+		Summary:           buildExportSummary(metrics),
+	}, "", "  ")
+}
+
+func buildReportJSON(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) ([]byte, error) {
+	if options.SummaryOnly {
+		return buildSummaryJSON(metrics, generatedAt, checkinWindow)
+	}
+	payload := buildReportPayload(items, metrics, generatedAt, checkinWindow)
+	payload.CohortDeadlines = buildCohortDeadlines(items, options.CohortDeadlines, generatedAt)
+	payload.CohortWindows = options.CohortWindows
+	if options.OwnerOverdueSLA >= 0 {
+		payload.OwnerOverdueSLA = &options.OwnerOverdueSLA
+	}
+	payload.OwnerSLABreaches = ownerSLABreaches(payload.Owners, options.OwnerOverdueSLA)
+	return json.MarshalIndent(payload, "", "  ")
+}
+
 func writeReportOutput(path string, content []byte) error {
 	if isStdoutTarget(path) {
 		fmt.Print(string(content))
@@ -1014,6 +1992,9 @@ func normalizeReportFormat(path, format string) (string, error) {
 		if ext == ".json" {
 			return "json", nil
 		}
+		if ext == ".md" || ext == ".markdown" {
+			return "markdown", nil
+		}
 		return "text", nil
 	}
 	if format == "text" || format == "txt" {
@@ -1022,6 +2003,12 @@ func normalizeReportFormat(path, format string) (string, error) {
 	if format == "json" {
 		return "json", nil
 	}
+	if format == "markdown" || format == "md" {
+		return "markdown", nil
+	}
+	if format == "table" {
+		return "table", nil
+	}
 	return "", fmt.Errorf("unsupported report format: %s", format)
 }
 
@@ -1029,53 +2016,131 @@ func buildReportPayload(items []awardItem, metrics summaryMetrics, generatedAt t
 	return reportPayload{
 		GeneratedAt:       generatedAt.Format(time.RFC3339),
 		CheckinWindowDays: checkinWindow,
-		Summary: exportSummary{
-			Count:          metrics.Count,
-			TotalAwarded:   metrics.TotalAwarded,
-			TotalDisbursed: metrics.TotalDisbursed,
-			TotalExpected:  metrics.TotalExpected,
-			TotalGap:       metrics.TotalGap,
-			Completion:     metrics.Completion,
-			Ahead:          metrics.Ahead,
-			OnTrack:        metrics.OnTrack,
-			Behind:         metrics.Behind,
-			Overdue:        metrics.Overdue,
-			DueSoon:        metrics.DueSoon,
-			High:           metrics.High,
-			Medium:         metrics.Medium,
-			Low:            metrics.Low,
-			Upcoming:       metrics.Upcoming,
-		},
-		Owners:   buildOwnerSummaries(items),
-		Cohorts:  buildCohortSummaries(items),
-		Statuses: buildStatusSummary(items),
-	}
-}
-
-func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int) string {
+		Summary:           buildExportSummary(metrics),
+		Owners:            buildOwnerSummaries(items),
+		Cohorts:           buildCohortSummaries(items),
+		Statuses:          buildStatusSummary(items),
+		Tags:              buildTagSummary(items),
+		OverdueBuckets:    buildOverdueBuckets(items),
+		Stalled:           buildStalledAwards(items),
+		Forecast:          combinedForecast(items),
+	}
+}
+
+// combinedForecast is the report's single forecast, or nil when the awards mix
+// currencies; forecast_by_currency carries the per-currency figures then.
+func combinedForecast(items []awardItem) *pacing.Forecast {
+	if len(buildCurrencyForecasts(items)) > 1 {
+		return nil
+	}
+	forecast := buildForecast(items)
+	return &forecast
+}
+
+func buildForecast(items []awardItem) pacing.Forecast {
+	total := pacing.Forecast{}
+	for _, item := range items {
+		total = total.Add(item.forecast)
+	}
+	return total
+}
+
+type currencyForecast struct {
+	Currency string `json:"currency"`
+	pacing.Forecast
+}
+
+// buildCurrencyForecasts totals the forecast per currency, like
+// buildCurrencyTotals, so mixed-currency data is never added together.
+func buildCurrencyForecasts(items []awardItem) []currencyForecast {
+	index := make(map[string]*currencyForecast)
+	for _, item := range items {
+		code := normalizeCurrency(item.data.Currency)
+		entry, ok := index[code]
+		if !ok {
+			entry = &currencyForecast{Currency: code}
+			index[code] = entry
+		}
+		entry.Forecast = entry.Forecast.Add(item.forecast)
+	}
+	forecasts := make([]currencyForecast, 0, len(index))
+	for _, entry := range index {
+		forecasts = append(forecasts, *entry)
+	}
+	sort.Slice(forecasts, func(i, j int) bool {
+		return forecasts[i].Currency < forecasts[j].Currency
+	})
+	return forecasts
+}
+
+func formatForecastLines(forecasts []currencyForecast) [][2]string {
+	horizon := func(amount func(pacing.Forecast) float64) string {
+		if len(forecasts) == 0 {
+			return formatCurrency(0, defaultCurrency)
+		}
+		parts := make([]string, 0, len(forecasts))
+		for _, forecast := range forecasts {
+			parts = append(parts, formatCurrency(amount(forecast.Forecast), forecast.Currency))
+		}
+		return strings.Join(parts, " · ")
+	}
+	return [][2]string{
+		{"Next 30 days", horizon(func(f pacing.Forecast) float64 { return f.Next30 })},
+		{"Next 60 days", horizon(func(f pacing.Forecast) float64 { return f.Next60 })},
+		{"Next 90 days", horizon(func(f pacing.Forecast) float64 { return f.Next90 })},
+	}
+}
+
+const (
+	defaultReportOwnerTop  = 5
+	defaultReportCohortTop = 4
+)
+
+func reportSectionLimits(top int) (owners, cohorts int) {
+	if top > 0 {
+		return top, top
+	}
+	return defaultReportOwnerTop, defaultReportCohortTop
+}
+
+func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) string {
+	ownerTop, cohortTop := reportSectionLimits(options.Top)
 	lines := []string{
 		"Group Scholar Pacing Report",
 		fmt.Sprintf("Generated: %s", generatedAt.Format(time.RFC3339)),
 		fmt.Sprintf("Check-in window: %d days", checkinWindow),
-		"",
-		fmt.Sprintf("Awards tracked: %d", metrics.Count),
-		fmt.Sprintf("Total awarded: %0.2f", metrics.TotalAwarded),
-		fmt.Sprintf("Total disbursed: %0.2f", metrics.TotalDisbursed),
-		fmt.Sprintf("Total expected: %0.2f", metrics.TotalExpected),
-		fmt.Sprintf("Total gap: %0.2f", metrics.TotalGap),
-		fmt.Sprintf("Completion: %0.1f%%", metrics.Completion*100),
-		fmt.Sprintf("Pace mix: Ahead %d · On track %d · Behind %d", metrics.Ahead, metrics.OnTrack, metrics.Behind),
+	}
+	if len(options.CohortWindows) > 0 {
+		lines = append(lines, "Cohort check-in windows: "+formatCohortWindows(options.CohortWindows))
+	}
+	lines = append(lines, "", fmt.Sprintf("Awards tracked: %d", metrics.Count))
+	for _, row := range summaryTotalRows(metrics) {
+		lines = append(lines, fmt.Sprintf("%s: %s", row[0], row[1]))
+	}
+	lines = append(lines,
+		"Pace mix: "+formatPaceMix(metrics),
 		fmt.Sprintf("Risk mix: High %d · Medium %d · Low %d", metrics.High, metrics.Medium, metrics.Low),
 		fmt.Sprintf("Check-ins: Overdue %d · Due soon %d", metrics.Overdue, metrics.DueSoon),
-	}
+	)
 	if len(metrics.Upcoming) > 0 {
 		lines = append(lines, fmt.Sprintf("Upcoming check-ins: %s", strings.Join(metrics.Upcoming, ", ")))
 	}
+	if options.SummaryOnly {
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	lines = append(lines, "", "Disbursement forecast:")
+	for _, row := range formatForecastLines(buildCurrencyForecasts(items)) {
+		lines = append(lines, fmt.Sprintf("- %s: %s", row[0], row[1]))
+	}
 
 	ownerSummaries := buildOwnerSummaries(items)
 	lines = append(lines, "", "Owner pulse:")
+	if breaches := ownerSLABreaches(ownerSummaries, options.OwnerOverdueSLA); len(breaches) > 0 {
+		lines = append(lines, "! "+formatOwnerSLALine(breaches, options.OwnerOverdueSLA))
+	}
 	for i, summary := range ownerSummaries {
-		if i >= 5 {
+		if i >= ownerTop {
 			break
 		}
 		lines = append(lines, fmt.Sprintf("- %s · %d awards · %d high · %d overdue · %s gap",
@@ -1083,13 +2148,21 @@ func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time
 			summary.Awards,
 			summary.High,
 			summary.Overdue,
-			formatSignedCurrency(summary.GapTotal),
+			formatOwnerGap(summary),
 		))
 	}
 	if len(ownerSummaries) == 0 {
 		lines = append(lines, "- None")
 	}
 
+	lines = append(lines, "", "Owner workload (by dollars):")
+	for _, summary := range buildOwnerWorkload(items) {
+		lines = append(lines, formatOwnerWorkloadLine(summary))
+	}
+	if len(ownerSummaries) == 0 {
+		lines = append(lines, "- None")
+	}
+
 	cohortSummaries := buildCohortSummaries(items)
 	lines = append(lines, "", "Cohort watchlist:")
 	cohortCount := 0
@@ -1097,14 +2170,15 @@ func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time
 		if summary.Behind == 0 && summary.GapTotal >= 0 {
 			continue
 		}
-		lines = append(lines, fmt.Sprintf("- %s · %d behind · %s gap · %0.1f%% complete",
+		lines = append(lines, fmt.Sprintf("- %s · %d behind · %s gap · %0.1f%% avg award complete · %0.1f%% of dollars disbursed",
 			summary.Cohort,
 			summary.Behind,
 			formatSignedCurrency(summary.GapTotal),
 			summary.Completion*100,
+			summary.DollarCompletion*100,
 		))
 		cohortCount++
-		if cohortCount >= 4 {
+		if cohortCount >= cohortTop {
 			break
 		}
 	}
@@ -1112,6 +2186,33 @@ func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time
 		lines = append(lines, "- None")
 	}
 
+	if deadlines := buildCohortDeadlines(items, options.CohortDeadlines, generatedAt); len(deadlines) > 0 {
+		lines = append(lines, "", "Cohort deadlines:")
+		for _, deadline := range deadlines {
+			lines = append(lines, formatCohortDeadlineLine(deadline))
+		}
+	}
+
+	if stalledAwards := buildStalledAwards(items); len(stalledAwards) > 0 {
+		lines = append(lines, "", "Stalled awards (no disbursement since the previous snapshot):")
+		for _, award := range stalledAwards {
+			lines = append(lines, fmt.Sprintf("- %s · %s · %s · %s of %s disbursed",
+				award.Scholar,
+				award.Cohort,
+				award.Owner,
+				formatCurrency(award.DisbursedToDate, award.Currency),
+				formatCurrency(award.Amount, award.Currency),
+			))
+		}
+	}
+
+	buckets := buildOverdueBuckets(items)
+	lines = append(lines, "", "Overdue check-ins by severity:",
+		fmt.Sprintf("- 1–7 days: %d", buckets.OneToSeven),
+		fmt.Sprintf("- 8–30 days: %d", buckets.EightToThirty),
+		fmt.Sprintf("- 31+ days: %d", buckets.ThirtyOneUp),
+	)
+
 	statusSummaries := buildStatusSummary(items)
 	statusParts := make([]string, 0, len(statusSummaries))
 	for _, summary := range statusSummaries {
@@ -1121,20 +2222,54 @@ func buildReportText(items []awardItem, metrics summaryMetrics, generatedAt time
 		lines = append(lines, "", fmt.Sprintf("Status mix: %s", strings.Join(statusParts, " · ")))
 	}
 
+	tagParts := make([]string, 0)
+	for _, summary := range buildTagSummary(items) {
+		tagParts = append(tagParts, fmt.Sprintf("%s %d", summary.Tag, summary.Count))
+	}
+	if len(tagParts) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Tag mix: %s", strings.Join(tagParts, " · ")))
+	}
+
 	return strings.Join(lines, "\n") + "\n"
 }
 
+func buildOverdueBuckets(items []awardItem) overdueBuckets {
+	var buckets overdueBuckets
+	for _, item := range items {
+		if item.check.Label != "Overdue" {
+			continue
+		}
+		days := -item.check.Days
+		switch {
+		case days <= 7:
+			buckets.OneToSeven++
+		case days <= 30:
+			buckets.EightToThirty++
+		default:
+			buckets.ThirtyOneUp++
+		}
+	}
+	return buckets
+}
+
 func buildOwnerSummaries(items []awardItem) []ownerSummary {
 	index := make(map[string]*ownerSummary)
 	for _, item := range items {
 		owner := item.data.Owner
 		entry, ok := index[owner]
+		code := normalizeCurrency(item.data.Currency)
 		if !ok {
-			entry = &ownerSummary{Owner: owner}
+			entry = &ownerSummary{Owner: owner, currency: code}
 			index[owner] = entry
+		} else if entry.currency != code {
+			entry.currency = ""
 		}
 		entry.Awards++
-		entry.GapTotal += item.pace.GapAmount
+		if hasAmount(item.data) {
+			entry.GapTotal += item.pace.GapAmount
+			entry.TotalAwarded += item.data.Amount
+			entry.TotalDisbursed += item.data.DisbursedToDate
+		}
 		if item.risk.Level == "High" {
 			entry.High++
 		}
@@ -1164,6 +2299,44 @@ func buildOwnerSummaries(items []awardItem) []ownerSummary {
 	return summaries
 }
 
+func buildOwnerWorkload(items []awardItem) []ownerSummary {
+	summaries := buildOwnerSummaries(items)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].TotalAwarded != summaries[j].TotalAwarded {
+			return summaries[i].TotalAwarded > summaries[j].TotalAwarded
+		}
+		if summaries[i].Awards != summaries[j].Awards {
+			return summaries[i].Awards > summaries[j].Awards
+		}
+		return strings.ToLower(summaries[i].Owner) < strings.ToLower(summaries[j].Owner)
+	})
+	return summaries
+}
+
+func (s ownerSummary) highShare() float64 {
+	if s.Awards == 0 {
+		return 0
+	}
+	return float64(s.High) / float64(s.Awards)
+}
+
+func formatOwnerWorkloadLine(summary ownerSummary) string {
+	if summary.currency == "" {
+		return fmt.Sprintf("- %s · %d awards · mixed currencies, not combined · %0.0f%% high risk",
+			summary.Owner,
+			summary.Awards,
+			summary.highShare()*100,
+		)
+	}
+	return fmt.Sprintf("- %s · %d awards · %s awarded · %s disbursed · %0.0f%% high risk",
+		summary.Owner,
+		summary.Awards,
+		formatCurrency(summary.TotalAwarded, summary.currency),
+		formatCurrency(summary.TotalDisbursed, summary.currency),
+		summary.highShare()*100,
+	)
+}
+
 func buildCohortSummaries(items []awardItem) []cohortSummary {
 	index := make(map[string]*cohortSummary)
 	for _, item := range items {
@@ -1174,18 +2347,25 @@ func buildCohortSummaries(items []awardItem) []cohortSummary {
 			index[cohort] = entry
 		}
 		entry.Awards++
+		if !hasAmount(item.data) {
+			entry.NoAmount++
+			continue
+		}
 		entry.GapTotal += item.pace.GapAmount
 		if item.pace.Label == "Behind" {
 			entry.Behind++
 		}
-		if item.data.Amount > 0 {
-			entry.Completion += item.data.DisbursedToDate / item.data.Amount
-		}
+		entry.Completion += item.data.DisbursedToDate / item.data.Amount
+		entry.awarded += item.data.Amount
+		entry.disbursed += item.data.DisbursedToDate
 	}
 	summaries := make([]cohortSummary, 0, len(index))
 	for _, entry := range index {
-		if entry.Awards > 0 {
-			entry.Completion = entry.Completion / float64(entry.Awards)
+		if funded := entry.Awards - entry.NoAmount; funded > 0 {
+			entry.Completion = entry.Completion / float64(funded)
+		}
+		if entry.awarded > 0 {
+			entry.DollarCompletion = entry.disbursed / entry.awarded
 		}
 		summaries = append(summaries, *entry)
 	}
@@ -1223,7 +2403,7 @@ func buildStatusSummary(items []awardItem) []statusSummary {
 	return summaries
 }
 
-func buildInsights(items []awardItem) string {
+func buildInsights(items []awardItem, ownerOverdueSLA int) string {
 	if len(items) == 0 {
 		return "No records loaded."
 	}
@@ -1233,6 +2413,9 @@ func buildInsights(items []awardItem) string {
 
 	ownerLines := make([]string, 0, 6)
 	ownerLines = append(ownerLines, "Owner pulse (top risk):")
+	if breaches := ownerSLABreaches(ownerSummaries, ownerOverdueSLA); len(breaches) > 0 {
+		ownerLines = append(ownerLines, statusBehind.Render(markerBehind+formatOwnerSLALine(breaches, ownerOverdueSLA)))
+	}
 	for i, summary := range ownerSummaries {
 		if i >= 5 {
 			break
@@ -1242,10 +2425,19 @@ func buildInsights(items []awardItem) string {
 			summary.Awards,
 			summary.High,
 			summary.Overdue,
-			formatSignedCurrency(summary.GapTotal),
+			formatOwnerGap(summary),
 		))
 	}
 
+	workloadLines := make([]string, 0, 6)
+	workloadLines = append(workloadLines, "Owner workload (by dollars):")
+	for i, summary := range buildOwnerWorkload(items) {
+		if i >= 5 {
+			break
+		}
+		workloadLines = append(workloadLines, formatOwnerWorkloadLine(summary))
+	}
+
 	cohortLines := make([]string, 0, 6)
 	cohortLines = append(cohortLines, "Cohort watchlist:")
 	cohortCount := 0
@@ -1277,12 +2469,13 @@ func buildInsights(items []awardItem) string {
 
 	return strings.Join([]string{
 		strings.Join(ownerLines, "\n"),
+		strings.Join(workloadLines, "\n"),
 		strings.Join(cohortLines, "\n"),
 		statusLine,
 	}, "\n\n")
 }
 
-func buildDetail(items []awardItem, index int) string {
+func buildDetail(items []awardItem, index int, width int) string {
 	if len(items) == 0 || index < 0 || index >= len(items) {
 		return "Select an award to see details."
 	}
@@ -1293,11 +2486,21 @@ func buildDetail(items []awardItem, index int) string {
 	risk := item.risk
 	checkinLine := "Not scheduled"
 	if !check.Date.IsZero() {
-		checkinLine = fmt.Sprintf("%s (%s)", check.Date.Format("Jan 2, 2006"), check.Label)
+		checkinLine = fmt.Sprintf("%s (%s)", formatDate(check.Date), check.Label)
 		if check.Days >= 0 {
-			checkinLine = fmt.Sprintf("%s (in %d days, %s)", check.Date.Format("Jan 2, 2006"), check.Days, check.Label)
+			checkinLine = fmt.Sprintf("%s (in %d days, %s)", formatDate(check.Date), check.Days, check.Label)
 		} else {
-			checkinLine = fmt.Sprintf("%s (%d days overdue)", check.Date.Format("Jan 2, 2006"), int(math.Abs(float64(check.Days))))
+			checkinLine = fmt.Sprintf("%s (%d days overdue)", formatDate(check.Date), int(math.Abs(float64(check.Days))))
+		}
+	}
+	lastLine := "Not recorded"
+	if !check.LastDate.IsZero() {
+		lastLine = fmt.Sprintf("%s (%d days ago)", formatDate(check.LastDate), check.SinceLast)
+		if check.Interval > 0 {
+			lastLine = fmt.Sprintf("%s (%d days ago; expected every %d days)", formatDate(check.LastDate), check.SinceLast, check.Interval)
+		}
+		if check.Lapsed {
+			lastLine += " · interval exceeded"
 		}
 	}
 	riskLine := risk.Level
@@ -1308,33 +2511,93 @@ func buildDetail(items []awardItem, index int) string {
 	if pace.GapAmount >= 0 {
 		gapDirection = "ahead"
 	}
-	return fmt.Sprintf(
-		"Scholar: %s\nCohort: %s\nOwner: %s\nStatus: %s\nAwarded: $%0.0f\nDisbursed: $%0.0f (%0.1f%%)\nExpected: %0.1f%% ($%0.0f)\nGap vs expected: %s (%s)\nPace: %s (%0.1f%%)\nRisk: %s\nCheck-in: %s\nNotes: %s",
+	detail := fmt.Sprintf(
+		"Scholar: %s\nCohort: %s\nOwner: %s\nStatus: %s\nAwarded: %s\nDisbursed: %s (%0.1f%%)\nExpected: %0.1f%% (%s)\nGap vs expected: %s (%s)\nPace: %s (%0.1f%%)",
 		record.Scholar,
 		record.Cohort,
 		record.Owner,
 		record.Status,
-		record.Amount,
-		record.DisbursedToDate,
+		formatCurrency(record.Amount, record.Currency),
+		formatCurrency(record.DisbursedToDate, record.Currency),
 		pace.Percent*100,
 		pace.Expected*100,
-		pace.ExpectedAmount,
-		formatSignedCurrency(pace.GapAmount),
+		formatCurrency(pace.ExpectedAmount, record.Currency),
+		formatSignedCurrencyIn(pace.GapAmount, record.Currency),
 		gapDirection,
 		pace.Label,
 		pace.Delta*100,
+	)
+	if bars := buildProgressBars(pace, width); bars != "" {
+		detail += "\n" + bars
+	}
+	detail += fmt.Sprintf("\nRisk: %s\nCheck-in: %s\nLast check-in: %s\nNotes: %s",
 		riskLine,
 		checkinLine,
+		lastLine,
 		record.Notes,
 	)
+	if len(record.Tags) > 0 {
+		detail += "\nTags: " + strings.Join(record.Tags, ", ")
+	}
+	if warning := formatPaceWarning(record, pace); warning != "" {
+		detail += "\nWarning: " + warning
+	}
+	return detail
+}
+
+// formatPaceWarning spells out a pacing data warning with the amounts involved.
+func formatPaceWarning(record Disbursement, pace paceStatus) string {
+	switch pace.Warning {
+	case pacing.WarnOverDisbursed:
+		return fmt.Sprintf("Disbursed exceeds awarded by %s (%0.1f%% of the award); check for a missing clawback or a wrong award amount",
+			formatCurrency(record.DisbursedToDate-record.Amount, record.Currency),
+			pace.Percent*100,
+		)
+	case pacing.WarnNegativeDisbursed:
+		return fmt.Sprintf("Disbursed to date is negative (%s), which looks like a data error; pacing treats it as 0",
+			formatCurrency(record.DisbursedToDate, record.Currency),
+		)
+	}
+	return ""
+}
+
+func (m model) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	cmds := make([]tea.Cmd, 0, 2)
+	if m.statePath != "" {
+		cmds = append(cmds, m.restoreState)
+	}
+	if m.watch.enabled {
+		cmds = append(cmds, m.watch.tick(m.watch.interval))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && m.editing {
+		return m.updateEdit(key)
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.showHelp {
+		switch key.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "?", "q", "esc":
+			m.showHelp = false
+		}
+		return m, nil
+	}
 	switch msg := msg.(type) {
+	case uiStateMsg:
+		m.applyUIState(msg)
+		return m, nil
+	case watchTickMsg, watchPollMsg, watchReloadMsg:
+		return m, m.updateWatch(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -1344,26 +2607,91 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.list.SetSize(msg.Width-4, listHeight)
 		m.ready = true
+		m.refreshPanels()
 		return m, nil
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "?":
+			if m.list.FilterState() != list.Filtering {
+				m.showHelp = true
+				return m, nil
+			}
 		case "r":
-			m.updatedAt = time.Now()
-			m.baseItems = buildItems(m.records, m.updatedAt, m.checkinWindowDays)
-			m.items = sortItems(applyFilter(m.baseItems, m.filterMode), m.sortMode)
+			m.updatedAt = m.now()
+			m.baseItems = applyItemFilters(m.buildAllItems(), m.filters)
+			m.items = m.visibleItems()
 			m.list.SetItems(itemsToList(m.items))
 			m.list.Select(0)
 		case "i":
 			m.showInsights = !m.showInsights
+			m.showCohorts = false
+			m.showOwnerGaps = false
+		case "c":
+			if m.list.FilterState() != list.Filtering {
+				m.showCohorts = !m.showCohorts
+				m.showInsights = false
+				m.showOwnerGaps = false
+			}
+		case "o":
+			if m.list.FilterState() != list.Filtering {
+				m.showOwnerGaps = !m.showOwnerGaps
+				m.showInsights = false
+				m.showCohorts = false
+			}
+		case "O", "C":
+			if m.list.FilterState() != list.Filtering {
+				if item, ok := m.list.SelectedItem().(awardItem); ok {
+					kind := "owner"
+					if msg.String() == "C" {
+						kind = "cohort"
+					}
+					m.setDrillDown(drillDownFor(kind, item))
+					m.showInsights = false
+					m.showCohorts = false
+					m.showOwnerGaps = false
+				}
+			}
+		case "esc":
+			if m.drill.active() && m.list.FilterState() == list.Unfiltered {
+				m.setDrillDown(drillDown{})
+				m.refreshPanels()
+				return m, nil
+			}
+		case "e":
+			if m.list.FilterState() != list.Filtering {
+				if item, ok := m.list.SelectedItem().(awardItem); ok {
+					return m.startEdit(item)
+				}
+			}
+		case "w":
+			if m.list.FilterState() != list.Filtering {
+				m.saveRecords()
+			}
+		case "t":
+			if m.list.FilterState() != list.Filtering {
+				m.compact = !m.compact
+				m.applyListDelegate()
+			}
+		case "n", "N":
+			if m.list.FilterState() != list.Filtering {
+				step := 1
+				if msg.String() == "N" {
+					step = -1
+				}
+				m.jumpToHighRisk(step)
+			}
 		case "s":
-			if m.sortMode == "priority" {
+			switch m.sortMode {
+			case "priority":
 				m.sortMode = "alpha"
-			} else {
+			case "alpha":
+				m.sortMode = "gap"
+			default:
 				m.sortMode = "priority"
 			}
-			m.items = sortItems(applyFilter(m.baseItems, m.filterMode), m.sortMode)
+			m.items = m.visibleItems()
 			m.list.SetItems(itemsToList(m.items))
 			m.list.Select(0)
 		case "f":
@@ -1375,7 +2703,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			default:
 				m.filterMode = "all"
 			}
-			m.items = sortItems(applyFilter(m.baseItems, m.filterMode), m.sortMode)
+			m.items = m.visibleItems()
 			m.list.SetItems(itemsToList(m.items))
 			m.list.Select(0)
 		}
@@ -1383,11 +2711,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
+	m.refreshPanels()
+	return m, cmd
+}
+
+func (m *model) refreshPanels() {
 	index := m.list.Index()
-	m.detail = buildDetail(m.items, index)
+	m.detail = buildDetail(m.items, index, sidePanelWidth(m.width))
+	if m.drill.active() {
+		m.detail = buildDrillDownPanel(m.baseItems, m.drill) + "\n\n" + m.detail
+	}
 	m.summary = buildSummary(calculateSummaryMetrics(m.items), m.checkinWindowDays)
-	m.insights = buildInsights(m.items)
-	return m, cmd
+	m.insights = buildInsights(m.items, m.ownerOverdueSLA)
+	m.cohortTable = buildCohortTable(m.items, sidePanelWidth(m.width))
+	if panel := buildCohortDeadlinePanel(buildCohortDeadlines(m.items, m.cohortDeadlines, m.updatedAt), sidePanelWidth(m.width)); panel != "" {
+		m.cohortTable += "\n\n" + panel
+	}
+	m.ownerGapChart = buildOwnerGapChart(m.items, sidePanelWidth(m.width))
+}
+
+func sidePanelWidth(termWidth int) int {
+	if termWidth <= 0 {
+		return 0
+	}
+	return max(termWidth/2-6, 24)
 }
 
 func (m model) View() string {
@@ -1395,9 +2742,21 @@ func (m model) View() string {
 		return "Loading award pacing console..."
 	}
 
+	if m.showHelp {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, buildHelpOverlay(m))
+	}
+
 	header := headerStyle.Render("Group Scholar Award Pacing Console")
-	meta := subtle.Render(fmt.Sprintf("Press / to filter · s to sort (%s) · f to focus (%s) · i for insights · r to refresh timestamp · q to quit", m.sortMode, m.filterMode))
-	stamp := subtle.Render("Updated " + m.updatedAt.Format("Jan 2 15:04"))
+	metaText := fmt.Sprintf("Press ? for help · / to filter · sort %s · focus %s · q to quit", m.sortMode, m.filterMode)
+	if m.drill.active() {
+		metaText += " · " + m.drill.label() + " (esc to clear)"
+	}
+	meta := subtle.Render(metaText)
+	stampText := "Updated " + formatShortDate(m.updatedAt) + " " + m.updatedAt.Format("15:04")
+	if !m.watch.checkedAt.IsZero() {
+		stampText += " · checked " + m.watch.checkedAt.Format("15:04:05")
+	}
+	stamp := subtle.Render(stampText)
 	lines := []string{header, meta, stamp}
 	if m.filterSummary != "" {
 		lines = append(lines, subtle.Render(m.filterSummary))
@@ -1407,11 +2766,21 @@ func (m model) View() string {
 	rightPanel := m.detail
 	if m.showInsights {
 		rightPanel = m.insights
+	} else if m.showCohorts {
+		rightPanel = m.cohortTable
+	} else if m.showOwnerGaps {
+		rightPanel = m.ownerGapChart
 	}
 	right := panel.Render(rightPanel)
 
 	columns := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 
-	lines = append(lines, accent.Render(m.summary), columns)
+	lines = append(lines, accent.Render(m.summary))
+	if m.editing {
+		lines = append(lines, m.editView())
+	} else if m.statusMessage != "" {
+		lines = append(lines, subtle.Render(m.statusMessage))
+	}
+	lines = append(lines, columns)
 	return strings.Join(lines, "\n\n")
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type metricGroup struct {
+	owner     string
+	cohort    string
+	currency  string
+	awards    int
+	behind    int
+	overdue   int
+	high      int
+	gap       float64
+	awarded   float64
+	disbursed float64
+}
+
+type metricFamily struct {
+	name  string
+	help  string
+	value func(metricGroup) float64
+}
+
+var pacingMetricFamilies = []metricFamily{
+	{"pacing_awards", "Awards tracked.", func(g metricGroup) float64 { return float64(g.awards) }},
+	{"pacing_total_gap", "Sum of disbursed minus expected amounts; negative means behind.", func(g metricGroup) float64 { return g.gap }},
+	{"pacing_behind_count", "Awards with a Behind pace label.", func(g metricGroup) float64 { return float64(g.behind) }},
+	{"pacing_overdue_count", "Awards with an overdue check-in.", func(g metricGroup) float64 { return float64(g.overdue) }},
+	{"pacing_high_risk_count", "Awards at High risk.", func(g metricGroup) float64 { return float64(g.high) }},
+	{"pacing_total_awarded", "Sum of award amounts.", func(g metricGroup) float64 { return g.awarded }},
+	{"pacing_total_disbursed", "Sum of amounts disbursed to date.", func(g metricGroup) float64 { return g.disbursed }},
+}
+
+// buildPrometheusMetrics renders gauges in the Prometheus text exposition
+// format, one series per owner, cohort, and currency, so money gauges never
+// add amounts in different currencies.
+func buildPrometheusMetrics(items []awardItem, generatedAt time.Time) string {
+	index := make(map[string]*metricGroup)
+	for _, item := range items {
+		owner := ownerLabel(item.data.Owner)
+		cohort := strings.TrimSpace(item.data.Cohort)
+		currency := normalizeCurrency(item.data.Currency)
+		key := owner + "\x00" + cohort + "\x00" + currency
+		group, ok := index[key]
+		if !ok {
+			group = &metricGroup{owner: owner, cohort: cohort, currency: currency}
+			index[key] = group
+		}
+		group.awards++
+		group.gap += item.pace.GapAmount
+		group.awarded += item.data.Amount
+		group.disbursed += item.data.DisbursedToDate
+		if item.pace.Label == "Behind" {
+			group.behind++
+		}
+		if item.check.Label == "Overdue" {
+			group.overdue++
+		}
+		if item.risk.Level == "High" {
+			group.high++
+		}
+	}
+	groups := make([]metricGroup, 0, len(index))
+	for _, group := range index {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].owner != groups[j].owner {
+			return groups[i].owner < groups[j].owner
+		}
+		if groups[i].cohort != groups[j].cohort {
+			return groups[i].cohort < groups[j].cohort
+		}
+		return groups[i].currency < groups[j].currency
+	})
+
+	var b strings.Builder
+	for _, family := range pacingMetricFamilies {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", family.name, family.help, family.name)
+		for _, group := range groups {
+			fmt.Fprintf(&b, "%s{owner=\"%s\",cohort=\"%s\",currency=\"%s\"} %s\n",
+				family.name,
+				escapeMetricLabel(group.owner),
+				escapeMetricLabel(group.cohort),
+				escapeMetricLabel(group.currency),
+				strconv.FormatFloat(family.value(group), 'g', -1, 64),
+			)
+		}
+	}
+	fmt.Fprintf(&b, "# HELP pacing_snapshot_timestamp_seconds When the served pacing data was computed.\n# TYPE pacing_snapshot_timestamp_seconds gauge\npacing_snapshot_timestamp_seconds %d\n", generatedAt.Unix())
+	return b.String()
+}
+
+func escapeMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// metricsHandler is what -metrics listens with; -serve mounts the same
+// /metrics route alongside its other endpoints.
+func (s *snapshotServer) metricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.serveHealth)
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	return mux
+}
+
+func (s *snapshotServer) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	if !allowRead(w, r) {
+		return
+	}
+	items, generatedAt, err := s.current()
+	if err != nil {
+		http.Error(w, "error loading data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, buildPrometheusMetrics(items, generatedAt))
+}
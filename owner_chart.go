@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func buildOwnerGapChart(items []awardItem, width int) string {
+	if len(items) == 0 {
+		return "No records loaded."
+	}
+	summaries := buildOwnerSummaries(items)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return math.Abs(summaries[i].GapTotal) > math.Abs(summaries[j].GapTotal)
+	})
+
+	labelWidth := 4
+	valueWidth := 0
+	maxGap := 0.0
+	for _, summary := range summaries {
+		labelWidth = max(labelWidth, lipgloss.Width(ownerLabel(summary.Owner)))
+		valueWidth = max(valueWidth, lipgloss.Width(formatOwnerGap(summary)))
+		if summary.currency != "" {
+			maxGap = math.Max(maxGap, math.Abs(summary.GapTotal))
+		}
+	}
+	labelWidth = min(labelWidth, 16)
+	if width <= 0 {
+		width = 60
+	}
+	barWidth := max(width-labelWidth-valueWidth-2, 4)
+
+	lines := []string{"Gap by owner:"}
+	for _, summary := range summaries {
+		length := 0
+		if maxGap > 0 && summary.currency != "" {
+			length = int(math.Round(math.Abs(summary.GapTotal) / maxGap * float64(barWidth)))
+		}
+		if length == 0 && summary.GapTotal != 0 && summary.currency != "" {
+			length = 1
+		}
+		style := statusAhead
+		if summary.GapTotal < 0 {
+			style = statusBehind
+		}
+		bar := style.Render(strings.Repeat("█", length)) + strings.Repeat(" ", barWidth-length)
+		lines = append(lines, fmt.Sprintf("%-*s %s %*s",
+			labelWidth,
+			truncateCell(ownerLabel(summary.Owner), labelWidth),
+			bar,
+			valueWidth,
+			formatOwnerGap(summary),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatOwnerGap shows the gap in the owner's currency; an owner whose awards
+// mix currencies gets no bar and a "mixed" label instead of a summed gap.
+func formatOwnerGap(summary ownerSummary) string {
+	if summary.currency == "" {
+		return "mixed"
+	}
+	return formatSignedCurrencyIn(summary.GapTotal, summary.currency)
+}
+
+func ownerLabel(owner string) string {
+	if strings.TrimSpace(owner) == "" {
+		return "Unassigned"
+	}
+	return owner
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ownerSLABreach struct {
+	Owner   string `json:"owner"`
+	Overdue int    `json:"overdue"`
+}
+
+// ownerSLABreaches lists owners carrying more overdue check-ins than
+// -owner-overdue-sla allows, most overdue first. A negative SLA disables it;
+// 0 means no owner may carry any overdue check-in.
+func ownerSLABreaches(summaries []ownerSummary, sla int) []ownerSLABreach {
+	if sla < 0 {
+		return nil
+	}
+	breaches := make([]ownerSLABreach, 0)
+	for _, summary := range summaries {
+		if summary.Overdue > sla {
+			breaches = append(breaches, ownerSLABreach{Owner: summary.Owner, Overdue: summary.Overdue})
+		}
+	}
+	sort.SliceStable(breaches, func(i, j int) bool {
+		return breaches[i].Overdue > breaches[j].Overdue
+	})
+	return breaches
+}
+
+func formatOwnerSLALine(breaches []ownerSLABreach, sla int) string {
+	parts := make([]string, 0, len(breaches))
+	for _, breach := range breaches {
+		parts = append(parts, fmt.Sprintf("%s %d", breach.Owner, breach.Overdue))
+	}
+	return fmt.Sprintf("SLA breach (over %d overdue check-ins): %s", sla, strings.Join(parts, " · "))
+}
+
+func checkOwnerSLA(items []awardItem, sla int) []string {
+	tripped := make([]string, 0)
+	for _, breach := range ownerSLABreaches(buildOwnerSummaries(items), sla) {
+		tripped = append(tripped, fmt.Sprintf("%s has %d overdue check-ins (owner-overdue-sla %d)", breach.Owner, breach.Overdue, sla))
+	}
+	return tripped
+}
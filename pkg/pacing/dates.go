@@ -0,0 +1,66 @@
+package pacing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const ISODateLayout = "2006-01-02"
+
+var defaultDateLayouts = []string{
+	ISODateLayout,
+	"2006/01/02",
+	"1/2/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// ValidateDateFormat checks a layout for Config.DateFormat; empty is valid and
+// keeps the default layouts.
+func ValidateDateFormat(layout string) error {
+	layout = strings.TrimSpace(layout)
+	if layout == "" {
+		return nil
+	}
+	reference := time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(layout, reference.Format(layout))
+	if err != nil || !parsed.Equal(reference) {
+		return fmt.Errorf("invalid date format %q (use a Go layout such as 01/02/2006 or 02/01/2006)", layout)
+	}
+	return nil
+}
+
+// ParseDate parses a record date with the default layouts, ISO first. It is
+// Config{}.ParseDate.
+func ParseDate(value string) (time.Time, bool) {
+	return Config{}.ParseDate(value)
+}
+
+// ParseDate parses a record date with c.DateFormat, then ISO dates, or with
+// the default layouts when no format is pinned.
+func (c Config) ParseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range c.dateLayouts() {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c Config) dateLayouts() []string {
+	layout := strings.TrimSpace(c.DateFormat)
+	if layout == "" {
+		return defaultDateLayouts
+	}
+	if layout == ISODateLayout {
+		return []string{layout}
+	}
+	return []string{layout, ISODateLayout}
+}
@@ -0,0 +1,591 @@
+// Package pacing computes disbursement pace, check-in status, and risk for
+// scholarship awards. The pacing console CLI is a thin layer over it.
+package pacing
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Disbursement struct {
+	Scholar             string           `json:"scholar" yaml:"scholar"`
+	Cohort              string           `json:"cohort" yaml:"cohort"`
+	Amount              float64          `json:"amount" yaml:"amount"`
+	DisbursedToDate     float64          `json:"disbursed_to_date" yaml:"disbursed_to_date"`
+	Currency            string           `json:"currency,omitempty" yaml:"currency,omitempty"`
+	AwardDate           string           `json:"award_date" yaml:"award_date"`
+	TargetDate          string           `json:"target_date" yaml:"target_date"`
+	NextCheckin         string           `json:"next_checkin" yaml:"next_checkin"`
+	Owner               string           `json:"owner" yaml:"owner"`
+	Status              string           `json:"status" yaml:"status"`
+	Notes               string           `json:"notes" yaml:"notes"`
+	Tags                []string         `json:"tags,omitempty" yaml:"tags,omitempty"`
+	GracePeriodDays     int              `json:"grace_period_days,omitempty" yaml:"grace_period_days,omitempty"`
+	LastCheckin         string           `json:"last_checkin,omitempty" yaml:"last_checkin,omitempty"`
+	CheckinIntervalDays int              `json:"checkin_interval_days,omitempty" yaml:"checkin_interval_days,omitempty"`
+	Milestones          []Milestone      `json:"milestones,omitempty" yaml:"milestones,omitempty"`
+	PlannedPayments     []PlannedPayment `json:"planned_payments,omitempty" yaml:"planned_payments,omitempty"`
+}
+
+type Milestone struct {
+	Date    string  `json:"date" yaml:"date"`
+	Percent float64 `json:"percent" yaml:"percent"`
+}
+
+type PlannedPayment struct {
+	Date   string  `json:"date" yaml:"date"`
+	Amount float64 `json:"amount" yaml:"amount"`
+}
+
+// Expectation bases, in the order CalculatePace prefers them.
+const (
+	BasisPlanned    = "planned payments"
+	BasisMilestones = "milestones"
+	BasisLinear     = "linear"
+)
+
+type PaceStatus struct {
+	Label          string
+	Basis          string
+	Delta          float64
+	Percent        float64
+	Expected       float64
+	Elapsed        float64
+	ExpectedAmount float64
+	GapAmount      float64
+	Warning        string
+}
+
+// PaceStatus warnings for disbursed amounts the schedule cannot explain.
+// Percent is left unclamped above 1 so over-disbursed awards read as such.
+const (
+	WarnNegativeDisbursed = "negative disbursed_to_date treated as 0"
+	WarnOverDisbursed     = "disbursed exceeds awarded"
+)
+
+// CheckinStatus describes the next scheduled check-in. When the award records
+// a last check-in, LastDate and SinceLast describe it, and Lapsed is set once
+// SinceLast exceeds the expected Interval, whatever is scheduled next.
+type CheckinStatus struct {
+	Label     string
+	Days      int
+	Date      time.Time
+	LastDate  time.Time
+	SinceLast int
+	Interval  int
+	Lapsed    bool
+}
+
+type RiskStatus struct {
+	Level string
+	Flags []string
+	Score int
+}
+
+type RiskConfig struct {
+	BehindWeight      int `json:"behind_weight"`
+	OverdueWeight     int `json:"overdue_weight"`
+	DueSoonWeight     int `json:"due_soon_weight"`
+	UnscheduledWeight int `json:"unscheduled_weight"`
+	UnknownPaceWeight int `json:"unknown_pace_weight"`
+	AheadWeight       int `json:"ahead_weight"`
+	StalledWeight     int `json:"stalled_weight"`
+	IntervalWeight    int `json:"interval_weight"`
+	HighThreshold     int `json:"high_threshold"`
+	MediumThreshold   int `json:"medium_threshold"`
+}
+
+// Config controls pace thresholds, risk scoring, and the calendar used for
+// check-in day math (Location nil keeps the caller's time zone).
+type Config struct {
+	AheadThreshold  float64
+	BehindThreshold float64
+	Risk            RiskConfig
+	Location        *time.Location
+	GapTolerance    GapTolerance
+	CheckinInterval int
+	// CompletedStatuses are record statuses that take an award out of active
+	// pacing; matching ignores case.
+	CompletedStatuses []string
+	// CohortCheckinWindows overrides the Due Soon window for a cohort, keyed
+	// by lowercased, trimmed cohort name.
+	CohortCheckinWindows map[string]int
+	// DateFormat pins the Go layout record dates are parsed with, ahead of ISO
+	// dates. Empty tries ISO, then common spreadsheet formats.
+	DateFormat string
+}
+
+// GapTolerance is the band around the expected amount inside which an award
+// counts as On Track: Value dollars, or Value percent of the award amount when
+// Percent is set. The zero value disables it.
+type GapTolerance struct {
+	Value   float64
+	Percent bool
+}
+
+// ParseGapTolerance reads "250" as dollars and "0.5%" as percent of amount.
+func ParseGapTolerance(value string) (GapTolerance, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return GapTolerance{}, nil
+	}
+	tolerance := GapTolerance{}
+	if strings.HasSuffix(trimmed, "%") {
+		tolerance.Percent = true
+		trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, "%"))
+	}
+	parsed, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || parsed < 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return GapTolerance{}, fmt.Errorf("gap tolerance must be a non-negative dollar amount or percent (e.g. 250 or 1%%), got %q", value)
+	}
+	tolerance.Value = parsed
+	return tolerance, nil
+}
+
+// Covers reports whether gap falls within the tolerance for an award of amount.
+func (t GapTolerance) Covers(gap, amount float64) bool {
+	if t.Value <= 0 {
+		return false
+	}
+	limit := t.Value
+	if t.Percent {
+		limit = math.Abs(amount) * t.Value / 100
+	}
+	return math.Abs(gap) <= limit
+}
+
+// Item is the computed pacing state for one record; Index is its position in
+// the input slice.
+type Item struct {
+	Index    int
+	Record   Disbursement
+	Pace     PaceStatus
+	Checkin  CheckinStatus
+	Risk     RiskStatus
+	Forecast Forecast
+}
+
+// Forecast is what an award is expected to disburse over the next 30, 60, and
+// 90 days: its expected-to-date at each horizon less what has already gone
+// out, so a Behind award's shortfall falls due in the first horizon.
+type Forecast struct {
+	Next30 float64 `json:"next_30_days"`
+	Next60 float64 `json:"next_60_days"`
+	Next90 float64 `json:"next_90_days"`
+}
+
+// Add returns the horizon-by-horizon sum of f and other.
+func (f Forecast) Add(other Forecast) Forecast {
+	return Forecast{Next30: f.Next30 + other.Next30, Next60: f.Next60 + other.Next60, Next90: f.Next90 + other.Next90}
+}
+
+// CalculateForecast follows the same expectation curve as CalculatePace;
+// awards with no curve (Unknown, No Amount, or Completed) forecast nothing.
+func CalculateForecast(record Disbursement, now time.Time, config Config) Forecast {
+	ahead := func(days int) float64 {
+		pace := CalculatePace(record, now.AddDate(0, 0, days), config)
+		if pace.Label == "Unknown" || pace.Label == "No Amount" || pace.Label == "Completed" {
+			return 0
+		}
+		return math.Max(0, pace.ExpectedAmount-math.Max(0, record.DisbursedToDate))
+	}
+	return Forecast{Next30: ahead(30), Next60: ahead(60), Next90: ahead(90)}
+}
+
+// DefaultCompletedStatuses mark closed-out awards unless a caller overrides them.
+var DefaultCompletedStatuses = []string{"Completed", "Closed", "Archived"}
+
+func DefaultConfig() Config {
+	return Config{
+		AheadThreshold:    0.1,
+		BehindThreshold:   0.1,
+		Risk:              DefaultRiskConfig(),
+		CompletedStatuses: append([]string(nil), DefaultCompletedStatuses...),
+	}
+}
+
+// IsCompleted reports whether the record's status is one of
+// config.CompletedStatuses.
+func IsCompleted(record Disbursement, config Config) bool {
+	status := strings.TrimSpace(record.Status)
+	if status == "" {
+		return false
+	}
+	for _, completed := range config.CompletedStatuses {
+		if strings.EqualFold(status, strings.TrimSpace(completed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func DefaultRiskConfig() RiskConfig {
+	return RiskConfig{
+		BehindWeight:      2,
+		OverdueWeight:     2,
+		DueSoonWeight:     1,
+		UnscheduledWeight: 1,
+		UnknownPaceWeight: 1,
+		AheadWeight:       -1,
+		StalledWeight:     1,
+		IntervalWeight:    1,
+		HighThreshold:     3,
+		MediumThreshold:   2,
+	}
+}
+
+func ValidateConfig(config Config) error {
+	if config.AheadThreshold <= 0 || config.AheadThreshold > 1 {
+		return fmt.Errorf("ahead threshold must be between 0 and 1, got %g", config.AheadThreshold)
+	}
+	if config.BehindThreshold <= 0 || config.BehindThreshold > 1 {
+		return fmt.Errorf("behind threshold must be between 0 and 1 (a shortfall magnitude), got %g", config.BehindThreshold)
+	}
+	if config.CheckinInterval < 0 {
+		return fmt.Errorf("check-in interval must not be negative, got %d", config.CheckinInterval)
+	}
+	if config.Risk.MediumThreshold > config.Risk.HighThreshold {
+		return fmt.Errorf("risk medium threshold (%d) must not exceed high threshold (%d)", config.Risk.MediumThreshold, config.Risk.HighThreshold)
+	}
+	return ValidateDateFormat(config.DateFormat)
+}
+
+// ParallelBuildThreshold is the record count at which BuildItems fans out
+// across GOMAXPROCS workers.
+const ParallelBuildThreshold = 2000
+
+// BuildItems computes pace, check-in, and risk for every record, preserving
+// input order. Negative check-in windows are treated as zero.
+func BuildItems(records []Disbursement, now time.Time, windowDays int, config Config) []Item {
+	items := make([]Item, len(records))
+	checkinWindow := windowDays
+	if checkinWindow < 0 {
+		checkinWindow = 0
+	}
+	if config.Location != nil {
+		now = now.In(config.Location)
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if len(records) < ParallelBuildThreshold || workers < 2 {
+		for i, record := range records {
+			items[i] = BuildItem(i, record, now, checkinWindow, config)
+		}
+		return items
+	}
+	chunk := (len(records) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(records); start += chunk {
+		end := min(start+chunk, len(records))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				items[i] = BuildItem(i, records[i], now, checkinWindow, config)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return items
+}
+
+// BuildItem computes a single record as-is: unlike BuildItems it does not
+// convert now to config.Location or clamp the check-in window.
+func BuildItem(index int, record Disbursement, now time.Time, checkinWindow int, config Config) Item {
+	pace := CalculatePace(record, now, config)
+	check := CheckinStatus{Label: "Completed"}
+	if pace.Label != "Completed" {
+		window := CheckinWindowFor(record, checkinWindow, config)
+		check = ApplyCheckinInterval(CalculateCheckin(record, now, window, config), record, now, config)
+	}
+	return Item{
+		Index:    index,
+		Record:   record,
+		Pace:     pace,
+		Checkin:  check,
+		Risk:     CalculateRisk(pace, check, config.Risk),
+		Forecast: CalculateForecast(record, now, config),
+	}
+}
+
+func CalculatePace(record Disbursement, now time.Time, config Config) PaceStatus {
+	if IsCompleted(record, config) {
+		return completedPace(record)
+	}
+	if record.Amount <= 0 {
+		return PaceStatus{Label: "No Amount"}
+	}
+	disbursed := record.DisbursedToDate
+	warning := ""
+	if disbursed < 0 {
+		disbursed = 0
+		warning = WarnNegativeDisbursed
+	} else if disbursed > record.Amount {
+		warning = WarnOverDisbursed
+	}
+	percent := disbursed / record.Amount
+	elapsed := 0.0
+	awardDate, awardOK := EffectiveStart(record, config)
+	targetDate, targetOK := config.ParseDate(record.TargetDate)
+	if awardOK && targetOK {
+		totalDays := math.Max(1, targetDate.Sub(awardDate).Hours()/24)
+		elapsedDays := math.Max(0, now.Sub(awardDate).Hours()/24)
+		elapsed = elapsedDays / totalDays
+	}
+	basis := BasisPlanned
+	expected, ok := plannedExpectation(record.PlannedPayments, record.Amount, now, config)
+	if !ok {
+		basis = BasisMilestones
+		expected, ok = milestoneExpectation(record.Milestones, now, config)
+	}
+	if !ok {
+		if !awardOK || !targetOK {
+			return PaceStatus{Label: "Unknown", Percent: percent, Warning: warning}
+		}
+		basis = BasisLinear
+		expected = clamp(elapsed, 0, 1)
+	}
+	expectedAmount := record.Amount * expected
+	gapAmount := disbursed - expectedAmount
+	label := PaceLabel(percent-expected, config)
+	if config.GapTolerance.Covers(gapAmount, record.Amount) {
+		label = "On Track"
+	}
+	return PaceStatus{
+		Label:          label,
+		Basis:          basis,
+		Delta:          percent - expected,
+		Percent:        percent,
+		Expected:       expected,
+		Elapsed:        elapsed,
+		ExpectedAmount: expectedAmount,
+		GapAmount:      gapAmount,
+		Warning:        warning,
+	}
+}
+
+// EffectiveStart is the award date pushed past any GracePeriodDays; the linear
+// schedule expects nothing before it.
+func EffectiveStart(record Disbursement, config Config) (time.Time, bool) {
+	awardDate, ok := config.ParseDate(record.AwardDate)
+	if !ok {
+		return time.Time{}, false
+	}
+	return awardDate.AddDate(0, 0, max(record.GracePeriodDays, 0)), true
+}
+
+func milestoneExpectation(milestones []Milestone, now time.Time, config Config) (float64, bool) {
+	expected := 0.0
+	valid := false
+	for _, milestone := range milestones {
+		date, ok := config.ParseDate(milestone.Date)
+		if !ok {
+			continue
+		}
+		valid = true
+		if date.After(now) {
+			continue
+		}
+		expected = math.Max(expected, clamp(milestone.Percent/100, 0, 1))
+	}
+	return expected, valid
+}
+
+func plannedExpectation(payments []PlannedPayment, amount float64, now time.Time, config Config) (float64, bool) {
+	planned := 0.0
+	valid := false
+	for _, payment := range payments {
+		date, ok := config.ParseDate(payment.Date)
+		if !ok {
+			continue
+		}
+		valid = true
+		if date.After(now) {
+			continue
+		}
+		planned += payment.Amount
+	}
+	if !valid || amount <= 0 {
+		return 0, valid
+	}
+	return clamp(planned/amount, 0, 1), true
+}
+
+// CheckinWindowFor is the Due Soon window for the record's cohort, falling
+// back to defaultWindow when the cohort has no override.
+func CheckinWindowFor(record Disbursement, defaultWindow int, config Config) int {
+	if window, ok := config.CohortCheckinWindows[strings.ToLower(strings.TrimSpace(record.Cohort))]; ok {
+		return window
+	}
+	return defaultWindow
+}
+
+// completedPace expects exactly what was disbursed, so a completed award adds
+// to awarded and disbursed totals without a gap.
+func completedPace(record Disbursement) PaceStatus {
+	disbursed := math.Max(0, record.DisbursedToDate)
+	pace := PaceStatus{Label: "Completed", ExpectedAmount: disbursed}
+	if record.Amount > 0 {
+		pace.Percent = disbursed / record.Amount
+		pace.Expected = pace.Percent
+	}
+	return pace
+}
+
+func CalculateCheckin(record Disbursement, now time.Time, windowDays int, config Config) CheckinStatus {
+	if record.NextCheckin == "" {
+		return CheckinStatus{Label: "Unscheduled"}
+	}
+	checkDate, ok := config.ParseDate(record.NextCheckin)
+	if !ok {
+		return CheckinStatus{Label: "Unscheduled"}
+	}
+	nowDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	checkDate = time.Date(checkDate.Year(), checkDate.Month(), checkDate.Day(), 0, 0, 0, 0, nowDate.Location())
+	daysUntil := int(math.Round(checkDate.Sub(nowDate).Hours() / 24))
+	label := "Scheduled"
+	if daysUntil < 0 {
+		label = "Overdue"
+	} else if daysUntil <= windowDays {
+		label = "Due Soon"
+	}
+	return CheckinStatus{Label: label, Days: daysUntil, Date: checkDate}
+}
+
+// ApplyCheckinInterval fills in the last check-in fields. The award's own
+// CheckinIntervalDays wins over config.CheckinInterval; zero means no
+// expectation.
+func ApplyCheckinInterval(check CheckinStatus, record Disbursement, now time.Time, config Config) CheckinStatus {
+	lastDate, ok := config.ParseDate(record.LastCheckin)
+	if !ok {
+		return check
+	}
+	nowDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	check.LastDate = time.Date(lastDate.Year(), lastDate.Month(), lastDate.Day(), 0, 0, 0, 0, nowDate.Location())
+	check.SinceLast = int(math.Round(nowDate.Sub(check.LastDate).Hours() / 24))
+	check.Interval = config.CheckinInterval
+	if record.CheckinIntervalDays > 0 {
+		check.Interval = record.CheckinIntervalDays
+	}
+	check.Lapsed = check.Interval > 0 && check.SinceLast > check.Interval
+	return check
+}
+
+func PaceLabel(delta float64, config Config) string {
+	if delta >= config.AheadThreshold {
+		return "Ahead"
+	}
+	if delta <= -config.BehindThreshold {
+		return "Behind"
+	}
+	return "On Track"
+}
+
+const (
+	FlagBehind      = "Behind pace"
+	FlagOverdue     = "Check-in overdue"
+	FlagDueSoon     = "Check-in due soon"
+	FlagUnscheduled = "Check-in unscheduled"
+	FlagUnknownPace = "Pace unknown (missing dates)"
+	FlagLapsed      = "Check-in interval exceeded"
+)
+
+func CalculateRisk(pace PaceStatus, check CheckinStatus, config RiskConfig) RiskStatus {
+	score := 0
+	flags := make([]string, 0, 3)
+	if pace.Label == "Behind" {
+		score += config.BehindWeight
+		flags = append(flags, FlagBehind)
+	}
+	if check.Label == "Overdue" {
+		score += config.OverdueWeight
+		flags = append(flags, FlagOverdue)
+	}
+	if check.Label == "Due Soon" {
+		score += config.DueSoonWeight
+		flags = append(flags, FlagDueSoon)
+	}
+	if check.Label == "Unscheduled" {
+		score += config.UnscheduledWeight
+		flags = append(flags, FlagUnscheduled)
+	}
+	if pace.Label == "Unknown" {
+		score += config.UnknownPaceWeight
+		flags = append(flags, FlagUnknownPace)
+	}
+	if check.Lapsed {
+		score += config.IntervalWeight
+		flags = append(flags, FlagLapsed)
+	}
+	if pace.Label == "Ahead" {
+		score += config.AheadWeight
+	}
+	return RiskStatus{Level: riskLevel(score, config), Flags: flags, Score: score}
+}
+
+// StalledFlag marks an award whose disbursed_to_date did not move between the
+// two most recent snapshots.
+const StalledFlag = "Disbursement stalled"
+
+// MarkStalled adds the stalled flag and weight to an already computed risk.
+func MarkStalled(risk RiskStatus, config RiskConfig) RiskStatus {
+	for _, flag := range risk.Flags {
+		if flag == StalledFlag {
+			return risk
+		}
+	}
+	score := risk.Score + config.StalledWeight
+	flags := append(append([]string(nil), risk.Flags...), StalledFlag)
+	return RiskStatus{Level: riskLevel(score, config), Flags: flags, Score: score}
+}
+
+type RiskContribution struct {
+	Reason string
+	Points int
+}
+
+// RiskBreakdown lists the points behind a computed risk: one entry per flag,
+// plus the ahead-of-pace credit, which carries no flag.
+func RiskBreakdown(risk RiskStatus, pace PaceStatus, config RiskConfig) []RiskContribution {
+	weights := map[string]int{
+		FlagBehind:      config.BehindWeight,
+		FlagOverdue:     config.OverdueWeight,
+		FlagDueSoon:     config.DueSoonWeight,
+		FlagUnscheduled: config.UnscheduledWeight,
+		FlagUnknownPace: config.UnknownPaceWeight,
+		FlagLapsed:      config.IntervalWeight,
+		StalledFlag:     config.StalledWeight,
+	}
+	contributions := make([]RiskContribution, 0, len(risk.Flags)+1)
+	for _, flag := range risk.Flags {
+		contributions = append(contributions, RiskContribution{Reason: flag, Points: weights[flag]})
+	}
+	if pace.Label == "Ahead" {
+		contributions = append(contributions, RiskContribution{Reason: "Ahead of pace", Points: config.AheadWeight})
+	}
+	return contributions
+}
+
+func riskLevel(score int, config RiskConfig) string {
+	if score >= config.HighThreshold {
+		return "High"
+	}
+	if score >= config.MediumThreshold {
+		return "Medium"
+	}
+	return "Low"
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
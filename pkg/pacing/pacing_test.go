@@ -0,0 +1,485 @@
+package pacing
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCalculatePaceBehind(t *testing.T) {
+	record := Disbursement{
+		Amount:          1000,
+		DisbursedToDate: 200,
+		AwardDate:       "2025-01-01",
+		TargetDate:      "2026-01-01",
+	}
+	now := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Label != "Behind" {
+		t.Fatalf("expected Behind, got %s", pace.Label)
+	}
+	if pace.ExpectedAmount <= 0 {
+		t.Fatalf("expected positive expected amount, got %0.2f", pace.ExpectedAmount)
+	}
+}
+
+func TestCalculateRiskHigh(t *testing.T) {
+	pace := PaceStatus{Label: "Behind"}
+	check := CheckinStatus{Label: "Overdue"}
+	risk := CalculateRisk(pace, check, DefaultRiskConfig())
+	if risk.Level != "High" {
+		t.Fatalf("expected High risk, got %s", risk.Level)
+	}
+	if risk.Score < 3 {
+		t.Fatalf("expected risk score >= 3, got %d", risk.Score)
+	}
+}
+
+func TestCalculatePaceMilestoneBoundary(t *testing.T) {
+	record := Disbursement{
+		Amount:          10000,
+		DisbursedToDate: 5000,
+		AwardDate:       "2025-01-01",
+		TargetDate:      "2026-01-01",
+		Milestones: []Milestone{
+			{Date: "2025-02-01", Percent: 25},
+			{Date: "2025-05-01", Percent: 50},
+			{Date: "2025-08-01", Percent: 75},
+		},
+	}
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Expected != 0.5 {
+		t.Fatalf("expected 50%% on milestone date, got %0.2f", pace.Expected)
+	}
+	if pace.ExpectedAmount != 5000 || pace.GapAmount != 0 {
+		t.Fatalf("expected $5000 expected and zero gap, got %0.2f / %0.2f", pace.ExpectedAmount, pace.GapAmount)
+	}
+	if pace.Label != "On Track" {
+		t.Fatalf("expected On Track, got %s", pace.Label)
+	}
+}
+
+func TestCalculatePaceBetweenMilestones(t *testing.T) {
+	record := Disbursement{
+		Amount:          10000,
+		DisbursedToDate: 2500,
+		AwardDate:       "2025-01-01",
+		TargetDate:      "2026-01-01",
+		Milestones: []Milestone{
+			{Date: "2025-02-01", Percent: 25},
+			{Date: "2025-08-01", Percent: 75},
+		},
+	}
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Expected != 0.25 {
+		t.Fatalf("expected step curve to hold at 25%%, got %0.2f", pace.Expected)
+	}
+	if pace.GapAmount != 0 {
+		t.Fatalf("expected zero gap against milestone curve, got %0.2f", pace.GapAmount)
+	}
+}
+
+func TestPaceLabelConfiguredThresholds(t *testing.T) {
+	if label := PaceLabel(0.07, DefaultConfig()); label != "On Track" {
+		t.Fatalf("expected On Track with default thresholds, got %s", label)
+	}
+	strict := Config{AheadThreshold: 0.05, BehindThreshold: 0.05}
+	if label := PaceLabel(0.07, strict); label != "Ahead" {
+		t.Fatalf("expected Ahead with 0.05 threshold, got %s", label)
+	}
+	if label := PaceLabel(-0.07, strict); label != "Behind" {
+		t.Fatalf("expected Behind with 0.05 threshold, got %s", label)
+	}
+	if err := ValidateConfig(Config{AheadThreshold: -0.1, BehindThreshold: 0.1}); err == nil {
+		t.Fatalf("expected error for negative ahead threshold")
+	}
+}
+
+func TestCalculateRiskCustomConfig(t *testing.T) {
+	pace := PaceStatus{Label: "On Track"}
+	check := CheckinStatus{Label: "Overdue"}
+	if risk := CalculateRisk(pace, check, DefaultRiskConfig()); risk.Level != "Medium" {
+		t.Fatalf("expected Medium with default config, got %s", risk.Level)
+	}
+	config := DefaultRiskConfig()
+	config.OverdueWeight = 4
+	if risk := CalculateRisk(pace, check, config); risk.Level != "High" {
+		t.Fatalf("expected High with heavier overdue weight, got %s", risk.Level)
+	}
+}
+
+func TestCalculateCheckinTimezoneDayBoundary(t *testing.T) {
+	record := Disbursement{NextCheckin: "2025-03-02"}
+
+	kiritimati := time.FixedZone("UTC+14", 14*60*60)
+	now := time.Date(2025, 3, 1, 0, 30, 0, 0, kiritimati)
+	if check := CalculateCheckin(record, now, 14, DefaultConfig()); check.Days != 1 {
+		t.Fatalf("expected check-in tomorrow in UTC+14, got %d days", check.Days)
+	}
+
+	pacific := time.FixedZone("PST", -8*60*60)
+	serverNow := time.Date(2025, 3, 2, 5, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.Location = pacific
+	items := BuildItems([]Disbursement{record}, serverNow, 14, config)
+	if items[0].Checkin.Days != 1 {
+		t.Fatalf("expected check-in tomorrow for a Pacific coordinator, got %d days", items[0].Checkin.Days)
+	}
+	config.Location = nil
+	items = BuildItems([]Disbursement{record}, serverNow, 14, config)
+	if items[0].Checkin.Days != 0 {
+		t.Fatalf("expected check-in today in UTC, got %d days", items[0].Checkin.Days)
+	}
+}
+
+func TestCalculatePaceUnknownForMissingDates(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 1000, DisbursedToDate: 0, NextCheckin: "2025-04-01"}
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Label != "Unknown" {
+		t.Fatalf("expected Unknown pace for empty dates, got %s", pace.Label)
+	}
+	record.AwardDate = "2025-01-01"
+	record.TargetDate = "not-a-date"
+	if pace := CalculatePace(record, now, DefaultConfig()); pace.Label != "Unknown" {
+		t.Fatalf("expected Unknown pace for invalid target date, got %s", pace.Label)
+	}
+	items := BuildItems([]Disbursement{record}, now, 14, DefaultConfig())
+	if items[0].Risk.Score != 1 || len(items[0].Risk.Flags) != 1 {
+		t.Fatalf("expected a single unknown-pace risk flag, got %+v", items[0].Risk)
+	}
+}
+
+func syntheticDisbursements(count int) []Disbursement {
+	records := make([]Disbursement, count)
+	for i := range records {
+		records[i] = Disbursement{
+			Scholar:         fmt.Sprintf("Scholar %d", i),
+			Cohort:          fmt.Sprintf("Cohort %d", i%12),
+			Amount:          float64(10000 + i),
+			DisbursedToDate: float64(i % 10000),
+			AwardDate:       "2025-01-01",
+			TargetDate:      "2025-12-31",
+			NextCheckin:     "2025-06-01",
+			Owner:           fmt.Sprintf("Owner %d", i%40),
+		}
+	}
+	return records
+}
+
+func TestBuildItemsParallelPreservesOrder(t *testing.T) {
+	records := syntheticDisbursements(ParallelBuildThreshold * 3)
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	items := BuildItems(records, now, 14, DefaultConfig())
+	if len(items) != len(records) {
+		t.Fatalf("expected %d items, got %d", len(records), len(items))
+	}
+	for i, item := range items {
+		if item.Index != i || item.Record.Scholar != records[i].Scholar {
+			t.Fatalf("item %d out of order: index %d scholar %s", i, item.Index, item.Record.Scholar)
+		}
+		expected := BuildItem(i, records[i], now, 14, DefaultConfig())
+		if item.Pace != expected.Pace || item.Checkin != expected.Checkin || item.Risk.Score != expected.Risk.Score {
+			t.Fatalf("item %d differs from serial build", i)
+		}
+	}
+}
+
+func BenchmarkBuildItemsSerial(b *testing.B) {
+	records := syntheticDisbursements(50000)
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items := make([]Item, len(records))
+		for j, record := range records {
+			items[j] = BuildItem(j, record, now, 14, config)
+		}
+	}
+}
+
+func BenchmarkBuildItemsParallel(b *testing.B) {
+	records := syntheticDisbursements(50000)
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BuildItems(records, now, 14, config)
+	}
+}
+
+func TestParseDateFormats(t *testing.T) {
+	want := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2026-01-02", "2026/01/02", "01/02/2026", "1/2/2026", "Jan 2, 2026", "January 2, 2026", "2 Jan 2026", " 2026-01-02 "} {
+		parsed, ok := ParseDate(value)
+		if !ok || !parsed.Equal(want) {
+			t.Fatalf("expected %q to parse as %s, got %s (ok=%v)", value, want.Format(ISODateLayout), parsed, ok)
+		}
+	}
+	if _, ok := ParseDate("next tuesday"); ok {
+		t.Fatalf("expected free text to be rejected")
+	}
+
+	dayFirst := DefaultConfig()
+	dayFirst.DateFormat = "02/01/2006"
+	if err := ValidateConfig(dayFirst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, ok := dayFirst.ParseDate("02/03/2026")
+	if !ok || !parsed.Equal(time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected day-first parse with pinned layout, got %s (ok=%v)", parsed, ok)
+	}
+	if _, ok := dayFirst.ParseDate("2026-03-02"); !ok {
+		t.Fatalf("expected ISO dates to remain valid with a pinned layout")
+	}
+	if _, ok := dayFirst.ParseDate("Mar 2, 2026"); ok {
+		t.Fatalf("expected other layouts to be rejected once pinned")
+	}
+	if parsed, _ := ParseDate("02/03/2026"); parsed.Month() != time.February {
+		t.Fatalf("expected a pinned config to leave the package default month-first, got %s", parsed)
+	}
+	record := Disbursement{Amount: 1000, DisbursedToDate: 500, AwardDate: "01/01/2026", TargetDate: "01/01/2027", NextCheckin: "10/03/2026"}
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	if pace := CalculatePace(record, now, dayFirst); pace.Label == "Unknown" {
+		t.Fatalf("expected pinned layout to feed pacing, got %+v", pace)
+	}
+	if check := CalculateCheckin(record, now, 14, dayFirst); check.Days != 9 {
+		t.Fatalf("expected check-in in 9 days, got %+v", check)
+	}
+	if check := CalculateCheckin(record, now, 14, DefaultConfig()); check.Days == 9 {
+		t.Fatalf("expected the default config to read the check-in month-first, got %+v", check)
+	}
+
+	dayFirst.DateFormat = "not a layout"
+	if err := ValidateConfig(dayFirst); err == nil {
+		t.Fatalf("expected invalid layout to be rejected")
+	}
+}
+
+func TestMarkStalledRaisesRisk(t *testing.T) {
+	config := DefaultRiskConfig()
+	risk := CalculateRisk(PaceStatus{Label: "On Track"}, CheckinStatus{Label: "Due Soon"}, config)
+	if risk.Level != "Low" {
+		t.Fatalf("expected Low before stall, got %s", risk.Level)
+	}
+	stalled := MarkStalled(risk, config)
+	if stalled.Level != "Medium" || stalled.Score != risk.Score+1 || stalled.Flags[len(stalled.Flags)-1] != StalledFlag {
+		t.Fatalf("expected stall to add a flag and bump to Medium, got %+v", stalled)
+	}
+	if len(risk.Flags) != 1 {
+		t.Fatalf("expected original flags untouched, got %v", risk.Flags)
+	}
+	if again := MarkStalled(stalled, config); again.Score != stalled.Score {
+		t.Fatalf("expected marking twice to be a no-op, got %+v", again)
+	}
+}
+
+func TestCalculatePacePlannedPaymentsOverrideLinear(t *testing.T) {
+	record := Disbursement{
+		Amount:          12000,
+		DisbursedToDate: 3000,
+		AwardDate:       "2025-01-01",
+		TargetDate:      "2026-01-01",
+	}
+	now := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	linear := CalculatePace(record, now, DefaultConfig())
+	if linear.Basis != BasisLinear || linear.Label != "Behind" {
+		t.Fatalf("expected linear Behind, got %+v", linear)
+	}
+
+	record.PlannedPayments = []PlannedPayment{
+		{Date: "2025-01-15", Amount: 3000},
+		{Date: "2025-07-02", Amount: 0},
+		{Date: "2025-09-01", Amount: 4500},
+		{Date: "2026-01-01", Amount: 4500},
+		{Date: "someday", Amount: 1000},
+	}
+	planned := CalculatePace(record, now, DefaultConfig())
+	if planned.Basis != BasisPlanned || planned.Expected != 0.25 || planned.ExpectedAmount != 3000 {
+		t.Fatalf("expected 25%% planned to date, got %+v", planned)
+	}
+	if planned.Label != "On Track" || planned.GapAmount != 0 {
+		t.Fatalf("expected On Track against the plan, got %s gap %0.2f", planned.Label, planned.GapAmount)
+	}
+	if planned.Elapsed != linear.Elapsed {
+		t.Fatalf("expected elapsed time unchanged by the plan, got %0.3f vs %0.3f", planned.Elapsed, linear.Elapsed)
+	}
+
+	record.Milestones = []Milestone{{Date: "2025-02-01", Percent: 75}}
+	if pace := CalculatePace(record, now, DefaultConfig()); pace.Basis != BasisPlanned {
+		t.Fatalf("expected planned payments to take precedence over milestones, got %s", pace.Basis)
+	}
+
+	record.AwardDate, record.TargetDate, record.Milestones = "", "", nil
+	if pace := CalculatePace(record, now, DefaultConfig()); pace.Label == "Unknown" {
+		t.Fatalf("expected planned payments to pace awards without dates, got %+v", pace)
+	}
+}
+
+func TestCalculatePaceGapToleranceAbsolute(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 20000, AwardDate: "2025-01-01", TargetDate: "2025-12-31"}
+	record.DisbursedToDate = CalculatePace(record, now, DefaultConfig()).ExpectedAmount - 2500
+	config := DefaultConfig()
+	config.BehindThreshold = 0.05
+	if pace := CalculatePace(record, now, config); pace.Label != "Behind" {
+		t.Fatalf("expected Behind without tolerance, got %s", pace.Label)
+	}
+	tolerance, err := ParseGapTolerance("3000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config.GapTolerance = tolerance
+	if pace := CalculatePace(record, now, config); pace.Label != "On Track" || pace.GapAmount > -2499 {
+		t.Fatalf("expected On Track with unchanged gap, got %+v", pace)
+	}
+	config.GapTolerance = GapTolerance{Value: 2000}
+	if pace := CalculatePace(record, now, config); pace.Label != "Behind" {
+		t.Fatalf("expected Behind outside tolerance, got %s", pace.Label)
+	}
+}
+
+func TestCalculatePaceGapTolerancePercent(t *testing.T) {
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 20000, AwardDate: "2025-01-01", TargetDate: "2025-12-31"}
+	record.DisbursedToDate = CalculatePace(record, now, DefaultConfig()).ExpectedAmount - 2500
+	config := DefaultConfig()
+	config.BehindThreshold = 0.05
+	tolerance, err := ParseGapTolerance("15%")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tolerance.Percent || tolerance.Value != 15 {
+		t.Fatalf("expected 15 percent tolerance, got %+v", tolerance)
+	}
+	config.GapTolerance = tolerance
+	if pace := CalculatePace(record, now, config); pace.Label != "On Track" {
+		t.Fatalf("expected On Track within 15%% of amount, got %s", pace.Label)
+	}
+	config.GapTolerance = GapTolerance{Value: 10, Percent: true}
+	if pace := CalculatePace(record, now, config); pace.Label != "Behind" {
+		t.Fatalf("expected Behind outside 10%% of amount, got %s", pace.Label)
+	}
+	if _, err := ParseGapTolerance("-5%"); err == nil {
+		t.Fatalf("expected error for negative tolerance")
+	}
+}
+
+func TestCalculatePaceInsideGracePeriod(t *testing.T) {
+	now := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 12000, AwardDate: "2025-01-01", TargetDate: "2025-12-31", GracePeriodDays: 30}
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Expected != 0 || pace.ExpectedAmount != 0 || pace.Label != "On Track" {
+		t.Fatalf("expected nothing due inside the grace period, got %+v", pace)
+	}
+	record.GracePeriodDays = 0
+	if pace := CalculatePace(record, now, DefaultConfig()); pace.Expected <= 0 {
+		t.Fatalf("expected linear pacing without a grace period, got %+v", pace)
+	}
+}
+
+func TestCalculatePaceJustPastGracePeriod(t *testing.T) {
+	now := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 12000, AwardDate: "2025-01-01", TargetDate: "2025-12-31", GracePeriodDays: 30}
+	pace := CalculatePace(record, now, DefaultConfig())
+	// Grace ends 2025-01-31, leaving 334 scheduled days of which 10 have passed.
+	want := 10.0 / 334.0
+	if math.Abs(pace.Expected-want) > 1e-9 {
+		t.Fatalf("expected %0.4f after the grace period, got %0.4f", want, pace.Expected)
+	}
+	record.GracePeriodDays = 0
+	if ungraced := CalculatePace(record, now, DefaultConfig()); ungraced.Expected <= pace.Expected {
+		t.Fatalf("expected the grace period to lower expected pace, got %0.4f vs %0.4f", ungraced.Expected, pace.Expected)
+	}
+}
+
+func TestCheckinIntervalFlagsLapsedAward(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{
+		Amount:          1000,
+		DisbursedToDate: 400,
+		AwardDate:       "2025-01-01",
+		TargetDate:      "2025-12-31",
+		NextCheckin:     "2025-06-15",
+		LastCheckin:     "2025-03-01",
+	}
+	config := DefaultConfig()
+	config.CheckinInterval = 30
+	item := BuildItem(0, record, now, 14, config)
+	if !item.Checkin.Lapsed || item.Checkin.SinceLast != 61 || item.Checkin.Interval != 30 {
+		t.Fatalf("expected lapsed check-in 61 days after the last one, got %+v", item.Checkin)
+	}
+	if item.Checkin.Label != "Scheduled" || !slices.Contains(item.Risk.Flags, FlagLapsed) {
+		t.Fatalf("expected interval flag despite a scheduled check-in, got %+v %+v", item.Checkin, item.Risk)
+	}
+
+	record.CheckinIntervalDays = 90
+	if item := BuildItem(0, record, now, 14, config); item.Checkin.Lapsed || slices.Contains(item.Risk.Flags, FlagLapsed) {
+		t.Fatalf("expected per-award interval to override the default, got %+v", item.Checkin)
+	}
+	record.CheckinIntervalDays = 0
+	if item := BuildItem(0, record, now, 14, DefaultConfig()); item.Checkin.Lapsed {
+		t.Fatalf("expected no interval expectation by default, got %+v", item.Checkin)
+	}
+}
+
+func TestCalculateForecastLinearAward(t *testing.T) {
+	now := time.Date(2025, 4, 11, 0, 0, 0, 0, time.UTC)
+	// 365-day award of 36,500 expects 100 a day; 100 days in, 10,000 is on pace.
+	record := Disbursement{Amount: 36500, DisbursedToDate: 10000, AwardDate: "2025-01-01", TargetDate: "2026-01-01"}
+	forecast := CalculateForecast(record, now, DefaultConfig())
+	want := Forecast{Next30: 3000, Next60: 6000, Next90: 9000}
+	if math.Abs(forecast.Next30-want.Next30) > 1e-6 || math.Abs(forecast.Next60-want.Next60) > 1e-6 || math.Abs(forecast.Next90-want.Next90) > 1e-6 {
+		t.Fatalf("expected %+v, got %+v", want, forecast)
+	}
+	record.DisbursedToDate = record.Amount
+	if forecast := CalculateForecast(record, now, DefaultConfig()); forecast != (Forecast{}) {
+		t.Fatalf("expected fully disbursed award to forecast nothing, got %+v", forecast)
+	}
+	if total := want.Add(want); total.Next90 != 18000 {
+		t.Fatalf("expected forecasts to add per horizon, got %+v", total)
+	}
+}
+
+func TestCalculatePaceOverDisbursed(t *testing.T) {
+	now := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 1000, DisbursedToDate: 1200, AwardDate: "2025-01-01", TargetDate: "2025-12-31"}
+	pace := CalculatePace(record, now, DefaultConfig())
+	if math.Abs(pace.Percent-1.2) > 1e-9 || pace.Warning != WarnOverDisbursed || pace.Label != "Ahead" {
+		t.Fatalf("expected unclamped 120%% with an over-disbursed warning, got %+v", pace)
+	}
+	if math.Abs(pace.GapAmount-(1200-pace.ExpectedAmount)) > 1e-9 {
+		t.Fatalf("expected gap from the full disbursed amount, got %+v", pace)
+	}
+}
+
+func TestCalculatePaceNegativeDisbursed(t *testing.T) {
+	now := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Amount: 1000, DisbursedToDate: -250, AwardDate: "2025-01-01", TargetDate: "2025-12-31"}
+	pace := CalculatePace(record, now, DefaultConfig())
+	if pace.Percent != 0 || pace.Warning != WarnNegativeDisbursed || pace.Label != "Behind" {
+		t.Fatalf("expected negative disbursed treated as 0 with a warning, got %+v", pace)
+	}
+	if math.Abs(pace.GapAmount+pace.ExpectedAmount) > 1e-9 {
+		t.Fatalf("expected gap measured from 0 disbursed, got %+v", pace)
+	}
+	if forecast := CalculateForecast(record, now, DefaultConfig()); forecast.Next90 > record.Amount {
+		t.Fatalf("expected forecast capped by the award, got %+v", forecast)
+	}
+}
+
+func TestBuildItemSkipsPacingForCompletedStatus(t *testing.T) {
+	now := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	record := Disbursement{Status: " Archived ", Amount: 1000, DisbursedToDate: 250, AwardDate: "2025-01-01", TargetDate: "2025-06-30", NextCheckin: "2025-05-01"}
+	item := BuildItem(0, record, now, 14, DefaultConfig())
+	if item.Pace.Label != "Completed" || item.Pace.GapAmount != 0 || item.Pace.ExpectedAmount != 250 {
+		t.Fatalf("expected completed pace with no gap, got %+v", item.Pace)
+	}
+	if item.Checkin.Label != "Completed" || len(item.Risk.Flags) != 0 || item.Forecast.Next90 != 0 {
+		t.Fatalf("expected no check-in, risk, or forecast for a completed award, got %+v %+v %+v", item.Checkin, item.Risk, item.Forecast)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// stopProfiling flushes any -cpuprofile/-memprofile output. It is a no-op
+// until startProfiling runs and safe to call more than once.
+var stopProfiling = func() {}
+
+func startProfiling(cpuPath, memPath string) error {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		file, err := os.Create(cpuPath)
+		if err != nil {
+			return fmt.Errorf("creating cpu profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return fmt.Errorf("starting cpu profile: %w", err)
+		}
+		cpuFile = file
+	}
+	stopped := false
+	stopProfiling = func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				logger.Warnf("could not write cpu profile: %v", err)
+			}
+		}
+		if memPath != "" {
+			if err := writeHeapProfile(memPath); err != nil {
+				logger.Warnf("could not write memory profile: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// exit flushes profiles before leaving, since os.Exit skips deferred calls.
+func exit(code int) {
+	stopProfiling()
+	os.Exit(code)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// buildProgressBars draws disbursed and expected progress on one scale, so
+// the offset between the bar ends reads as ahead or behind. width is the side
+// panel width, which stays 0 until the first WindowSizeMsg; no bars until then.
+func buildProgressBars(pace paceStatus, width int) string {
+	if width <= 0 || pace.Label == "Unknown" || pace.Label == "No Amount" || pace.Label == "Completed" {
+		return ""
+	}
+	barWidth := max(width-len("Expected ")-len(" 100.0%"), 4)
+	return fmt.Sprintf("Actual   %s %5.1f%%\nExpected %s %5.1f%%",
+		progressBar(pace.Percent, barWidth, paceBarStyle(pace.Label)),
+		pace.Percent*100,
+		progressBar(pace.Expected, barWidth, subtle),
+		pace.Expected*100,
+	)
+}
+
+func progressBar(fraction float64, width int, style lipgloss.Style) string {
+	fraction = math.Min(math.Max(fraction, 0), 1)
+	filled := int(math.Round(fraction * float64(width)))
+	return style.Render(strings.Repeat("█", filled)) + subtle.Render(strings.Repeat("░", width-filled))
+}
+
+func paceBarStyle(label string) lipgloss.Style {
+	switch label {
+	case "Ahead":
+		return statusAhead
+	case "Behind":
+		return statusBehind
+	}
+	return statusOn
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func buildReportMarkdown(items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options reportOptions) string {
+	ownerTop, cohortTop := reportSectionLimits(options.Top)
+	header := fmt.Sprintf("Generated: %s · Check-in window: %d days", generatedAt.Format(time.RFC3339), checkinWindow)
+	if len(options.CohortWindows) > 0 {
+		header += " (" + formatCohortWindows(options.CohortWindows) + ")"
+	}
+	lines := []string{
+		"# Group Scholar Pacing Report",
+		"",
+		header,
+		"",
+		"## Summary",
+		"",
+		"| Metric | Value |",
+		"| --- | --- |",
+		fmt.Sprintf("| Awards tracked | %d |", metrics.Count),
+	}
+	for _, row := range summaryTotalRows(metrics) {
+		lines = append(lines, fmt.Sprintf("| %s | %s |", row[0], markdownCell(row[1])))
+	}
+	lines = append(lines,
+		fmt.Sprintf("| Pace mix | %s |", formatPaceMix(metrics)),
+		fmt.Sprintf("| Risk mix | High %d · Medium %d · Low %d |", metrics.High, metrics.Medium, metrics.Low),
+		fmt.Sprintf("| Check-ins | Overdue %d · Due soon %d |", metrics.Overdue, metrics.DueSoon),
+	)
+	if len(metrics.Upcoming) > 0 {
+		lines = append(lines, fmt.Sprintf("| Upcoming check-ins | %s |", markdownCell(strings.Join(metrics.Upcoming, ", "))))
+	}
+	if options.SummaryOnly {
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	lines = append(lines, "", "## Disbursement forecast", "", "| Horizon | Expected to disburse |", "| --- | ---: |")
+	for _, row := range formatForecastLines(buildCurrencyForecasts(items)) {
+		lines = append(lines, fmt.Sprintf("| %s | %s |", row[0], row[1]))
+	}
+
+	ownerSummaries := buildOwnerSummaries(items)
+	lines = append(lines, "", "## Owner pulse", "")
+	if breaches := ownerSLABreaches(ownerSummaries, options.OwnerOverdueSLA); len(breaches) > 0 {
+		lines = append(lines, "**"+markdownCell(formatOwnerSLALine(breaches, options.OwnerOverdueSLA))+"**", "")
+	}
+	if len(ownerSummaries) == 0 {
+		lines = append(lines, "_None_")
+	} else {
+		lines = append(lines, "| Owner | Awards | High | Overdue | Gap |", "| --- | ---: | ---: | ---: | ---: |")
+		for i, summary := range ownerSummaries {
+			if i >= ownerTop {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("| %s | %d | %d | %d | %s |",
+				markdownCell(summary.Owner),
+				summary.Awards,
+				summary.High,
+				summary.Overdue,
+				formatOwnerGap(summary),
+			))
+		}
+	}
+
+	workload := buildOwnerWorkload(items)
+	lines = append(lines, "", "## Owner workload", "")
+	if len(workload) == 0 {
+		lines = append(lines, "_None_")
+	} else {
+		lines = append(lines, "| Owner | Awards | Awarded | Disbursed | High risk |", "| --- | ---: | ---: | ---: | ---: |")
+		for _, summary := range workload {
+			awarded, disbursed := "mixed", "mixed"
+			if summary.currency != "" {
+				awarded = formatCurrency(summary.TotalAwarded, summary.currency)
+				disbursed = formatCurrency(summary.TotalDisbursed, summary.currency)
+			}
+			lines = append(lines, fmt.Sprintf("| %s | %d | %s | %s | %0.0f%% |",
+				markdownCell(summary.Owner),
+				summary.Awards,
+				awarded,
+				disbursed,
+				summary.highShare()*100,
+			))
+		}
+	}
+
+	cohortSummaries := buildCohortSummaries(items)
+	cohortLines := []string{"| Cohort | Behind | Gap | Avg award complete | Dollars disbursed |", "| --- | ---: | ---: | ---: | ---: |"}
+	cohortCount := 0
+	for _, summary := range cohortSummaries {
+		if summary.Behind == 0 && summary.GapTotal >= 0 {
+			continue
+		}
+		cohortLines = append(cohortLines, fmt.Sprintf("| %s | %d | %s | %0.1f%% | %0.1f%% |",
+			markdownCell(summary.Cohort),
+			summary.Behind,
+			formatSignedCurrency(summary.GapTotal),
+			summary.Completion*100,
+			summary.DollarCompletion*100,
+		))
+		cohortCount++
+		if cohortCount >= cohortTop {
+			break
+		}
+	}
+	lines = append(lines, "", "## Cohort watchlist", "")
+	if cohortCount == 0 {
+		lines = append(lines, "_None_")
+	} else {
+		lines = append(lines, cohortLines...)
+	}
+
+	if deadlines := buildCohortDeadlines(items, options.CohortDeadlines, generatedAt); len(deadlines) > 0 {
+		lines = append(lines, "", "## Cohort deadlines", "", "| Cohort | Deadline | Days left | Dollars disbursed | Projected completion | Status |", "| --- | --- | ---: | ---: | --- | --- |")
+		for _, deadline := range deadlines {
+			projected, status := deadline.Projected, "On time"
+			if projected == "" {
+				projected = "—"
+			}
+			if deadline.Late {
+				status = "**Late**"
+			}
+			lines = append(lines, fmt.Sprintf("| %s | %s | %d | %0.1f%% | %s | %s |",
+				markdownCell(deadline.label()),
+				deadline.Deadline,
+				deadline.DaysToDeadline,
+				deadline.DollarCompletion*100,
+				projected,
+				status,
+			))
+		}
+	}
+
+	if stalledAwards := buildStalledAwards(items); len(stalledAwards) > 0 {
+		lines = append(lines, "", "## Stalled awards", "", "_No disbursement since the previous snapshot._", "", "| Scholar | Cohort | Owner | Disbursed | Awarded |", "| --- | --- | --- | ---: | ---: |")
+		for _, award := range stalledAwards {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s | %s | %s |",
+				markdownCell(award.Scholar),
+				markdownCell(award.Cohort),
+				markdownCell(award.Owner),
+				formatCurrency(award.DisbursedToDate, award.Currency),
+				formatCurrency(award.Amount, award.Currency),
+			))
+		}
+	}
+
+	buckets := buildOverdueBuckets(items)
+	lines = append(lines, "", "## Overdue check-ins by severity", "", "| Days overdue | Check-ins |", "| --- | ---: |",
+		fmt.Sprintf("| 1–7 | %d |", buckets.OneToSeven),
+		fmt.Sprintf("| 8–30 | %d |", buckets.EightToThirty),
+		fmt.Sprintf("| 31+ | %d |", buckets.ThirtyOneUp),
+	)
+
+	statusSummaries := buildStatusSummary(items)
+	if len(statusSummaries) > 0 {
+		lines = append(lines, "", "## Status mix", "", "| Status | Count |", "| --- | ---: |")
+		for _, summary := range statusSummaries {
+			lines = append(lines, fmt.Sprintf("| %s | %d |", markdownCell(summary.Status), summary.Count))
+		}
+	}
+
+	if tagSummaries := buildTagSummary(items); len(tagSummaries) > 0 {
+		lines = append(lines, "", "## Tag mix", "", "| Tag | Awards |", "| --- | ---: |")
+		for _, summary := range tagSummaries {
+			lines = append(lines, fmt.Sprintf("| %s | %d |", markdownCell(summary.Tag), summary.Count))
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type splitReportFile struct {
+	Key   string
+	Path  string
+	Count int
+}
+
+func normalizeSplitKey(key string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(key))
+	switch normalized {
+	case "owner", "cohort":
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown report split key: %s (use owner or cohort)", key)
+}
+
+func writeSplitReports(path, format, splitBy string, items []awardItem, generatedAt time.Time, checkinWindow int, options reportOptions) ([]splitReportFile, error) {
+	if isStdoutTarget(path) {
+		return nil, errors.New("split reports need a file path, not stdout")
+	}
+	key, err := normalizeSplitKey(splitBy)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]awardItem)
+	for _, item := range items {
+		value := strings.TrimSpace(item.data.Owner)
+		if key == "cohort" {
+			value = strings.TrimSpace(item.data.Cohort)
+		}
+		if value == "" {
+			value = "Unassigned"
+		}
+		groups[value] = append(groups[value], item)
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	used := make(map[string]int)
+	files := make([]splitReportFile, 0, len(names))
+	for _, name := range names {
+		slug := slugify(name)
+		used[slug]++
+		if used[slug] > 1 {
+			slug = fmt.Sprintf("%s-%d", slug, used[slug])
+		}
+		target := fmt.Sprintf("%s-%s%s", base, slug, ext)
+		groupItems := groups[name]
+		metrics := calculateSummaryMetrics(groupItems)
+		if err := writeReport(target, format, groupItems, metrics, generatedAt, checkinWindow, options); err != nil {
+			return files, err
+		}
+		files = append(files, splitReportFile{Key: name, Path: target, Count: len(groupItems)})
+	}
+	return files, nil
+}
+
+func slugify(value string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && builder.Len() > 0 {
+			builder.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(builder.String(), "-")
+	if slug == "" {
+		return "unnamed"
+	}
+	return slug
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const reportTableCellWidth = 28
+
+var reportTableColumns = []string{"Scholar", "Cohort", "Owner", "Pace", "Gap", "Check-in", "Risk"}
+
+// buildReportTable renders one aligned row per award. Pace and risk cells are
+// styled only when color is set, and the active theme decides what that means.
+func buildReportTable(items []awardItem, generatedAt time.Time, checkinWindow int, color bool) string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			truncateCell(item.data.Scholar, reportTableCellWidth),
+			truncateCell(item.data.Cohort, reportTableCellWidth),
+			truncateCell(ownerLabel(item.data.Owner), reportTableCellWidth),
+			item.pace.Label,
+			formatSignedCurrencyIn(item.pace.GapAmount, item.data.Currency),
+			reportTableCheckin(item.check),
+			item.risk.Level,
+		})
+	}
+	widths := make([]int, len(reportTableColumns))
+	for i, column := range reportTableColumns {
+		widths[i] = lipgloss.Width(column)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	plain := lipgloss.NewStyle()
+	header := make([]string, len(reportTableColumns))
+	rule := make([]string, len(reportTableColumns))
+	for i, column := range reportTableColumns {
+		style := plain
+		if color {
+			style = headerStyle
+		}
+		header[i] = reportTableCell(column, widths[i], i == 4, style)
+		rule[i] = strings.Repeat("-", widths[i])
+	}
+	lines := []string{
+		fmt.Sprintf("Group Scholar Pacing Table · Generated %s · Check-in window %d days", generatedAt.Format(time.RFC3339), checkinWindow),
+		"",
+		strings.TrimRight(strings.Join(header, "  "), " "),
+		strings.Join(rule, "  "),
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			style := plain
+			if color && i == 3 {
+				style = paceStyle(cell)
+			}
+			if color && i == 6 {
+				style = riskStyle(cell)
+			}
+			cells[i] = reportTableCell(cell, widths[i], i == 4, style)
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	if len(rows) == 0 {
+		lines = append(lines, "No awards.")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func reportTableCell(value string, width int, alignRight bool, style lipgloss.Style) string {
+	pad := strings.Repeat(" ", max(width-lipgloss.Width(value), 0))
+	if alignRight {
+		return pad + style.Render(value)
+	}
+	return style.Render(value) + pad
+}
+
+func reportTableCheckin(check checkinStatus) string {
+	if check.Label == "Unscheduled" {
+		return check.Label
+	}
+	return fmt.Sprintf("%s (%s)", check.Label, formatDaysLabel(check.Days))
+}
+
+func paceStyle(label string) lipgloss.Style {
+	switch label {
+	case "Ahead":
+		return statusAhead
+	case "Behind":
+		return statusBehind
+	case "Unknown", "No Amount", "Completed":
+		return subtle
+	}
+	return statusOn
+}
+
+func riskStyle(level string) lipgloss.Style {
+	switch level {
+	case "High":
+		return statusBehind
+	case "Medium":
+		return statusOn
+	}
+	return subtle
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func saveData(path string, records []Disbursement) error {
+	indent := "  "
+	trailingNewline := true
+	mode := os.FileMode(0o644)
+	var extras map[string][]extraField
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		if original, err := os.ReadFile(path); err == nil {
+			indent = detectJSONIndent(original)
+			trailingNewline = bytes.HasSuffix(original, []byte("\n"))
+			extras = unknownJSONFields(original)
+		}
+	}
+
+	var compact bytes.Buffer
+	compact.WriteByte('[')
+	for i, record := range records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if fields := extras[recordKey(record)]; len(fields) > 0 {
+			encoded = encoded[:len(encoded)-1]
+			for _, field := range fields {
+				key, _ := json.Marshal(field.key)
+				encoded = append(append(append(append(encoded, ','), key...), ':'), field.value...)
+			}
+			encoded = append(encoded, '}')
+		}
+		if i > 0 {
+			compact.WriteByte(',')
+		}
+		compact.Write(encoded)
+	}
+	compact.WriteByte(']')
+
+	var formatted bytes.Buffer
+	var err error
+	if indent == "" {
+		err = json.Compact(&formatted, compact.Bytes())
+	} else {
+		err = json.Indent(&formatted, compact.Bytes(), "", indent)
+	}
+	if err != nil {
+		return err
+	}
+	content := formatted.Bytes()
+	if trailingNewline {
+		content = append(content, '\n')
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Chmod(mode); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+type extraField struct {
+	key   string
+	value json.RawMessage
+}
+
+// unknownJSONFields collects the keys in the original data file that
+// Disbursement does not model, per scholar + cohort, so a save carries them
+// through instead of dropping them.
+func unknownJSONFields(content []byte) map[string][]extraField {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(content, &objects); err != nil {
+		return nil
+	}
+	known := make(map[string]struct{})
+	recordType := reflect.TypeOf(Disbursement{})
+	for i := 0; i < recordType.NumField(); i++ {
+		name, _, _ := strings.Cut(recordType.Field(i).Tag.Get("json"), ",")
+		known[strings.ToLower(name)] = struct{}{}
+	}
+	extras := make(map[string][]extraField)
+	for _, object := range objects {
+		fields := make([]extraField, 0)
+		for key, value := range object {
+			if _, ok := known[strings.ToLower(key)]; !ok {
+				fields = append(fields, extraField{key: key, value: value})
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+		var record Disbursement
+		raw, _ := json.Marshal(object)
+		if err := json.Unmarshal(raw, &record); err != nil {
+			continue
+		}
+		extras[recordKey(record)] = fields
+	}
+	return extras
+}
+
+func detectJSONIndent(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || len(trimmed) == len(line) {
+			continue
+		}
+		return line[:len(line)-len(trimmed)]
+	}
+	return ""
+}
+
+func saveTargetError(source, path string) error {
+	switch source {
+	case "db":
+		return errors.New("cannot save: data was loaded from Postgres")
+	case "stdin":
+		return errors.New("cannot save: data was read from stdin")
+	}
+	if strings.Contains(path, ",") || strings.ContainsAny(path, "*?[") {
+		return errors.New("cannot save: data was merged from multiple files")
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		return fmt.Errorf("cannot save: %s is not a JSON data file", path)
+	}
+	return nil
+}
+
+func saveBlockedReason(duplicates int) string {
+	if duplicates > 0 {
+		return "cannot save: duplicate records were dropped on load"
+	}
+	return ""
+}
+
+// filteredSaveReason refuses a save while record filters are active: the
+// in-memory records are only the matching subset, so writing them back would
+// delete every hidden award from the file.
+func filteredSaveReason(filters recordFilters) string {
+	if buildRecordFilterSummary(filters) != "" {
+		return "cannot save: records are filtered, so saving would drop the hidden ones"
+	}
+	return ""
+}
+
+func (m model) saveBlockReason() string {
+	if reason := filteredSaveReason(m.filters); reason != "" {
+		return reason
+	}
+	return m.saveBlocked
+}
+
+func (m *model) saveRecords() {
+	if reason := m.saveBlockReason(); reason != "" {
+		m.statusMessage = reason
+		return
+	}
+	if err := saveTargetError(m.dataSource, m.dataPath); err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+	if err := saveData(m.dataPath, m.records); err != nil {
+		m.statusMessage = "error saving data: " + err.Error()
+		return
+	}
+	m.unsavedEdits = false
+	m.statusMessage = fmt.Sprintf("Saved %d records to %s.", len(m.records), m.dataPath)
+}
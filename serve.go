@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const serveShutdownTimeout = 5 * time.Second
+
+// snapshotServer answers /snapshot, /report, /metrics, and /healthz. With reload unset
+// it serves the items loaded at startup; otherwise each request re-reads the
+// data through reload.
+type snapshotServer struct {
+	mu            sync.Mutex
+	items         []awardItem
+	generatedAt   time.Time
+	checkinWindow int
+	options       reportOptions
+	reload        func() ([]awardItem, time.Time, error)
+}
+
+func (s *snapshotServer) current() ([]awardItem, time.Time, error) {
+	if s.reload == nil {
+		return s.items, s.generatedAt, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, generatedAt, err := s.reload()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	s.items, s.generatedAt = items, generatedAt
+	return items, generatedAt, nil
+}
+
+func (s *snapshotServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.serveHealth)
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		if !allowRead(w, r) {
+			return
+		}
+		filterMode, err := normalizeFilterMode(r.URL.Query().Get("filter"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		items, generatedAt, err := s.current()
+		if err != nil {
+			http.Error(w, "error loading data: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		items = sortItems(applyFilter(items, filterMode), "priority")
+		content, err := buildSnapshotJSON(items, calculateSummaryMetrics(items), generatedAt, s.checkinWindow)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	})
+	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		if !allowRead(w, r) {
+			return
+		}
+		items, generatedAt, err := s.current()
+		if err != nil {
+			http.Error(w, "error loading data: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		items = sortItems(items, "priority")
+		metrics := calculateSummaryMetrics(items)
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			content, err := buildReportJSON(items, metrics, generatedAt, s.checkinWindow, s.options)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(content)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, buildReportText(items, metrics, generatedAt, s.checkinWindow, s.options))
+	})
+	return mux
+}
+
+func (s *snapshotServer) serveHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowRead(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+func allowRead(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+func newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// serveSnapshots runs every server until one fails or SIGINT/SIGTERM arrives,
+// then drains in-flight requests for up to serveShutdownTimeout.
+func serveSnapshots(servers ...*http.Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *http.Server) {
+			errs <- server.ListenAndServe()
+		}(server)
+	}
+	var failed error
+	select {
+	case failed = <-errs:
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil && failed == nil {
+			failed = err
+		}
+	}
+	if failed != nil {
+		return failed
+	}
+	for range servers {
+		if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func notifySlack(webhookURL string, items []awardItem, generatedAt time.Time, mentions map[string]string) (int, error) {
+	text, count := buildSlackMessage(items, generatedAt, mentions)
+	return count, postSlackMessage(webhookURL, text)
+}
+
+func buildSlackMessage(items []awardItem, generatedAt time.Time, mentions map[string]string) (string, int) {
+	flagged := make([]awardItem, 0)
+	for _, item := range items {
+		if item.risk.Level == "High" || item.check.Label == "Overdue" {
+			flagged = append(flagged, item)
+		}
+	}
+	lines := []string{
+		fmt.Sprintf("*Group Scholar pacing nudge* · %s", generatedAt.Format("Jan 2, 2006")),
+	}
+	if len(flagged) == 0 {
+		lines = append(lines, "No high-risk or overdue awards. :tada:")
+		return strings.Join(lines, "\n"), 0
+	}
+	lines = append(lines, fmt.Sprintf("%d awards need attention:", len(flagged)))
+	for _, item := range flagged {
+		reasons := make([]string, 0, 2)
+		if item.risk.Level == "High" {
+			reasons = append(reasons, "High risk")
+		}
+		if item.check.Label == "Overdue" {
+			reasons = append(reasons, fmt.Sprintf("check-in %d days overdue", -item.check.Days))
+		}
+		lines = append(lines, fmt.Sprintf("• *%s* (%s) · %s · %s gap · %s",
+			escapeSlackText(item.data.Scholar),
+			escapeSlackText(item.data.Cohort),
+			strings.Join(reasons, ", "),
+			formatSignedCurrencyIn(item.pace.GapAmount, item.data.Currency),
+			slackOwnerMention(item.data.Owner, mentions),
+		))
+	}
+	return strings.Join(lines, "\n"), len(flagged)
+}
+
+func slackOwnerMention(owner string, mentions map[string]string) string {
+	if id, ok := mentions[strings.ToLower(strings.TrimSpace(owner))]; ok && id != "" {
+		return "<@" + id + ">"
+	}
+	if strings.TrimSpace(owner) == "" {
+		return "no owner"
+	}
+	return "@" + escapeSlackText(owner)
+}
+
+func escapeSlackText(value string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(value)
+}
+
+func loadSlackMentions(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]string
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	mentions := make(map[string]string, len(raw))
+	for owner, id := range raw {
+		mentions[strings.ToLower(strings.TrimSpace(owner))] = strings.TrimSpace(id)
+	}
+	return mentions, nil
+}
+
+func postSlackMessage(webhookURL, text string) error {
+	body, err := json.Marshal(slackPayload{Text: text})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
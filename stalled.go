@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"groupscholar-pacing-console/pkg/pacing"
+)
+
+type stalledAward struct {
+	Scholar         string  `json:"scholar"`
+	Cohort          string  `json:"cohort"`
+	Owner           string  `json:"owner"`
+	Amount          float64 `json:"amount"`
+	DisbursedToDate float64 `json:"disbursed_to_date"`
+	Currency        string  `json:"currency,omitempty"`
+}
+
+func markStalledItems(items []awardItem, stalled map[string]struct{}, config riskConfig) []awardItem {
+	if len(stalled) == 0 {
+		return items
+	}
+	for i, item := range items {
+		if _, ok := stalled[recordKey(item.data)]; !ok || item.pace.Label == "Completed" {
+			continue
+		}
+		items[i] = newAwardItem(pacing.Item{
+			Index:    item.index,
+			Record:   item.data,
+			Pace:     item.pace,
+			Checkin:  item.check,
+			Risk:     pacing.MarkStalled(item.risk, config),
+			Forecast: item.forecast,
+		})
+		items[i].dateFormat = item.dateFormat
+	}
+	return items
+}
+
+func buildStalledAwards(items []awardItem) []stalledAward {
+	stalled := make([]stalledAward, 0)
+	for _, item := range items {
+		if !slices.Contains(item.risk.Flags, pacing.StalledFlag) {
+			continue
+		}
+		stalled = append(stalled, stalledAward{
+			Scholar:         item.data.Scholar,
+			Cohort:          item.data.Cohort,
+			Owner:           item.data.Owner,
+			Amount:          item.data.Amount,
+			DisbursedToDate: item.data.DisbursedToDate,
+			Currency:        item.data.Currency,
+		})
+	}
+	sort.SliceStable(stalled, func(i, j int) bool {
+		remainingI := stalled[i].Amount - stalled[i].DisbursedToDate
+		remainingJ := stalled[j].Amount - stalled[j].DisbursedToDate
+		if remainingI != remainingJ {
+			return remainingI > remainingJ
+		}
+		return strings.ToLower(stalled[i].Scholar) < strings.ToLower(stalled[j].Scholar)
+	})
+	return stalled
+}
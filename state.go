@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type uiState struct {
+	SortMode          string `json:"sort_mode"`
+	FilterMode        string `json:"filter_mode"`
+	ShowInsights      bool   `json:"show_insights"`
+	CheckinWindowDays int    `json:"checkin_window_days"`
+}
+
+type uiStateMsg struct {
+	state uiState
+	found bool
+	err   error
+}
+
+func defaultStatePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pacing-console", "state.json")
+}
+
+func loadUIState(path string) (uiState, bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return uiState{}, false, nil
+	}
+	if err != nil {
+		return uiState{}, false, err
+	}
+	var state uiState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return uiState{}, false, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return state, true, nil
+}
+
+func saveUIState(path string, state uiState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
+
+func (m model) restoreState() tea.Msg {
+	state, found, err := loadUIState(m.statePath)
+	return uiStateMsg{state: state, found: found, err: err}
+}
+
+func (m *model) applyUIState(msg uiStateMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Could not restore preferences: %v", msg.err)
+		return
+	}
+	if !msg.found {
+		return
+	}
+	state := msg.state
+	switch state.SortMode {
+	case "priority", "alpha", "gap":
+		m.sortMode = state.SortMode
+	}
+	if mode, err := normalizeFilterMode(state.FilterMode); err == nil {
+		m.filterMode = mode
+	}
+	m.showInsights = state.ShowInsights
+	if !m.windowFromFlag && state.CheckinWindowDays >= 0 && state.CheckinWindowDays != m.checkinWindowDays {
+		m.checkinWindowDays = state.CheckinWindowDays
+		m.baseItems = applyItemFilters(m.buildAllItems(), m.filters)
+	}
+	m.items = m.visibleItems()
+	m.list.SetItems(itemsToList(m.items))
+	m.list.Select(0)
+	m.refreshPanels()
+}
+
+func (m model) currentUIState() uiState {
+	return uiState{
+		SortMode:          m.sortMode,
+		FilterMode:        m.filterMode,
+		ShowInsights:      m.showInsights,
+		CheckinWindowDays: m.checkinWindowDays,
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type tagSummary struct {
+	Tag   string
+	Count int
+}
+
+func normalizeTagMode(mode string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(mode))
+	switch normalized {
+	case "", "any":
+		return "any", nil
+	case "all":
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown tag mode: %s (use any or all)", mode)
+}
+
+// matchesTags reports whether a record carries any (or, in "all" mode, every)
+// wanted tag. Tags compare case-insensitively.
+func matchesTags(tags []string, wanted map[string]struct{}, mode string) bool {
+	have := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		have[strings.ToLower(strings.TrimSpace(tag))] = struct{}{}
+	}
+	for tag := range wanted {
+		_, ok := have[tag]
+		if ok && mode != "all" {
+			return true
+		}
+		if !ok && mode == "all" {
+			return false
+		}
+	}
+	return mode == "all"
+}
+
+// buildTagSummary counts awards per tag, grouping spellings that differ only
+// in case under the first one seen. Untagged awards are left out.
+func buildTagSummary(items []awardItem) []tagSummary {
+	counts := make(map[string]int)
+	labels := make(map[string]string)
+	for _, item := range items {
+		seen := make(map[string]struct{}, len(item.data.Tags))
+		for _, tag := range item.data.Tags {
+			tag = strings.TrimSpace(tag)
+			key := strings.ToLower(tag)
+			if key == "" {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if _, ok := labels[key]; !ok {
+				labels[key] = tag
+			}
+			counts[key]++
+		}
+	}
+	summaries := make([]tagSummary, 0, len(counts))
+	for key, count := range counts {
+		summaries = append(summaries, tagSummary{Tag: labels[key], Count: count})
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return strings.ToLower(summaries[i].Tag) < strings.ToLower(summaries[j].Tag)
+	})
+	return summaries
+}
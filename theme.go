@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	accent       lipgloss.Style
+	subtle       lipgloss.Style
+	panel        lipgloss.Style
+	headerStyle  lipgloss.Style
+	statusAhead  lipgloss.Style
+	statusOn     lipgloss.Style
+	statusBehind lipgloss.Style
+	markerAhead  string
+	markerOn     string
+	markerBehind string
+	activeTheme  string
+	plainOutput  bool
+)
+
+func init() {
+	configureColorOutput()
+	_ = applyTheme("default")
+}
+
+// configureColorOutput disables styling when NO_COLOR is set or stdout is not
+// a terminal; applyTheme then hands out unstyled styles for every theme.
+func configureColorOutput() {
+	plainOutput = os.Getenv("NO_COLOR") != "" || !stdoutIsTerminal()
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func applyTheme(name string) error {
+	theme := strings.ToLower(strings.TrimSpace(name))
+	if theme == "" {
+		theme = "default"
+	}
+	panel = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2)
+	markerAhead, markerOn, markerBehind = "", "", ""
+	switch theme {
+	case "default":
+		accent = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+		subtle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+		headerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("63")).Bold(true)
+		statusAhead = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+		statusOn = lipgloss.NewStyle().Foreground(lipgloss.Color("69")).Bold(true)
+		statusBehind = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+	case "colorblind":
+		accent = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+		subtle = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+		headerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+		statusAhead = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true)
+		statusOn = lipgloss.NewStyle().Foreground(lipgloss.Color("229"))
+		statusBehind = lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Underline(true)
+		markerAhead, markerOn, markerBehind = "▲ ", "● ", "▼ "
+	case "mono":
+		accent = lipgloss.NewStyle()
+		subtle = lipgloss.NewStyle()
+		headerStyle = lipgloss.NewStyle()
+		statusAhead = lipgloss.NewStyle()
+		statusOn = lipgloss.NewStyle()
+		statusBehind = lipgloss.NewStyle()
+		markerAhead, markerOn, markerBehind = "[+] ", "[~] ", "[!] "
+	default:
+		return fmt.Errorf("unknown theme: %s (use default, colorblind, or mono)", name)
+	}
+	if plainOutput {
+		accent = lipgloss.NewStyle()
+		subtle = lipgloss.NewStyle()
+		headerStyle = lipgloss.NewStyle()
+		statusAhead = lipgloss.NewStyle()
+		statusOn = lipgloss.NewStyle()
+		statusBehind = lipgloss.NewStyle()
+	}
+	activeTheme = theme
+	return nil
+}
@@ -3,45 +3,78 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// Money totals and deltas are null when a snapshot mixes currencies, or when
+// the two snapshots compared are in different currencies.
 type trendSnapshot struct {
-	GeneratedAt    string  `json:"generated_at"`
-	RecordCount    int     `json:"record_count"`
-	TotalAwarded   float64 `json:"total_awarded"`
-	TotalDisbursed float64 `json:"total_disbursed"`
-	Ahead          int     `json:"ahead"`
-	OnTrack        int     `json:"on_track"`
-	Behind         int     `json:"behind"`
-	Overdue        int     `json:"overdue"`
-	DueSoon        int     `json:"due_soon"`
-	High           int     `json:"high"`
-	Medium         int     `json:"medium"`
-	Low            int     `json:"low"`
-	DueSoonWindow  int     `json:"due_soon_window"`
+	GeneratedAt    string   `json:"generated_at"`
+	RecordCount    int      `json:"record_count"`
+	Currencies     []string `json:"currencies,omitempty"`
+	TotalAwarded   *float64 `json:"total_awarded"`
+	TotalDisbursed *float64 `json:"total_disbursed"`
+	Ahead          int      `json:"ahead"`
+	OnTrack        int      `json:"on_track"`
+	Behind         int      `json:"behind"`
+	Overdue        int      `json:"overdue"`
+	DueSoon        int      `json:"due_soon"`
+	High           int      `json:"high"`
+	Medium         int      `json:"medium"`
+	Low            int      `json:"low"`
+	TotalRisk      int      `json:"total_risk_score"`
+	DueSoonWindow  int      `json:"due_soon_window"`
 }
 
 type trendDelta struct {
-	RecordCount    int     `json:"record_count"`
-	TotalAwarded   float64 `json:"total_awarded"`
-	TotalDisbursed float64 `json:"total_disbursed"`
-	Ahead          int     `json:"ahead"`
-	OnTrack        int     `json:"on_track"`
-	Behind         int     `json:"behind"`
-	Overdue        int     `json:"overdue"`
-	DueSoon        int     `json:"due_soon"`
-	High           int     `json:"high"`
-	Medium         int     `json:"medium"`
-	Low            int     `json:"low"`
+	RecordCount    int      `json:"record_count"`
+	TotalAwarded   *float64 `json:"total_awarded"`
+	TotalDisbursed *float64 `json:"total_disbursed"`
+	Ahead          int      `json:"ahead"`
+	OnTrack        int      `json:"on_track"`
+	Behind         int      `json:"behind"`
+	Overdue        int      `json:"overdue"`
+	DueSoon        int      `json:"due_soon"`
+	High           int      `json:"high"`
+	Medium         int      `json:"medium"`
+	Low            int      `json:"low"`
+	TotalRisk      int      `json:"total_risk_score"`
 }
 
 type trendReportPayload struct {
-	GeneratedAt string        `json:"generated_at"`
-	Current     trendSnapshot `json:"current"`
-	Previous    trendSnapshot `json:"previous"`
-	Delta       trendDelta    `json:"delta"`
+	GeneratedAt string                `json:"generated_at"`
+	Current     trendSnapshot         `json:"current"`
+	Previous    trendSnapshot         `json:"previous"`
+	Delta       trendDelta            `json:"delta"`
+	Velocity    *disbursementVelocity `json:"disbursement_velocity,omitempty"`
+}
+
+// Snapshots less than a day apart are measured over a one-day floor so a
+// sync-twice-an-hour habit does not annualize into a meaningless figure.
+const minVelocityWindowDays = 1.0
+
+type disbursementVelocity struct {
+	Currency    string  `json:"currency"`
+	ElapsedDays float64 `json:"elapsed_days"`
+	PerDay      float64 `json:"per_day"`
+	Annualized  float64 `json:"annualized"`
+	Floored     bool    `json:"floored,omitempty"`
+	Negative    bool    `json:"negative,omitempty"`
+}
+
+type trendSeriesPoint struct {
+	Snapshot   trendSnapshot `json:"snapshot"`
+	Completion float64       `json:"completion"`
+	Delta      *trendDelta   `json:"delta,omitempty"`
+}
+
+type trendSeriesPayload struct {
+	GeneratedAt string                `json:"generated_at"`
+	Points      []trendSeriesPoint    `json:"points"`
+	Velocity    *disbursementVelocity `json:"disbursement_velocity,omitempty"`
 }
 
 func writeTrendReport(path, format string, current, previous snapshotStats, generatedAt time.Time) error {
@@ -49,6 +82,9 @@ func writeTrendReport(path, format string, current, previous snapshotStats, gene
 	if err != nil {
 		return err
 	}
+	if format == "markdown" || format == "table" {
+		return fmt.Errorf("unsupported trend report format: %s", format)
+	}
 	if format == "json" {
 		payload := buildTrendReportPayload(current, previous, generatedAt)
 		content, err := json.MarshalIndent(payload, "", "  ")
@@ -67,7 +103,61 @@ func buildTrendReportPayload(current, previous snapshotStats, generatedAt time.T
 		Current:     buildTrendSnapshot(current),
 		Previous:    buildTrendSnapshot(previous),
 		Delta:       buildTrendDelta(current, previous),
+		Velocity:    comparableVelocity(current, previous),
+	}
+}
+
+// snapshotCurrency is the one currency a snapshot's totals are in; ok is
+// false when the snapshot mixes currencies.
+func snapshotCurrency(stats snapshotStats) (string, bool) {
+	switch len(stats.Currencies) {
+	case 0:
+		return defaultCurrency, true
+	case 1:
+		return stats.Currencies[0], true
 	}
+	return "", false
+}
+
+// trendCurrency is the currency shared by both snapshots, so their totals
+// can be subtracted; ok is false otherwise.
+func trendCurrency(current, previous snapshotStats) (string, bool) {
+	currentCode, currentOK := snapshotCurrency(current)
+	previousCode, previousOK := snapshotCurrency(previous)
+	if !currentOK || !previousOK || currentCode != previousCode {
+		return "", false
+	}
+	return currentCode, true
+}
+
+func comparableVelocity(current, previous snapshotStats) *disbursementVelocity {
+	if _, ok := trendCurrency(current, previous); !ok {
+		return nil
+	}
+	velocity := buildDisbursementVelocity(current, previous)
+	return &velocity
+}
+
+func formatTrendTotals(stats snapshotStats) string {
+	code, ok := snapshotCurrency(stats)
+	if !ok {
+		return fmt.Sprintf("totals not combined (mixed currencies: %s)", strings.Join(stats.Currencies, ", "))
+	}
+	return fmt.Sprintf("%s awarded · %s disbursed",
+		formatCurrencyCents(stats.TotalAwarded, code),
+		formatCurrencyCents(stats.TotalDisbursed, code),
+	)
+}
+
+func formatTrendMoneyDelta(current, previous snapshotStats) string {
+	code, ok := trendCurrency(current, previous)
+	if !ok {
+		return "money not compared (mixed currencies)"
+	}
+	return fmt.Sprintf("awarded %s · disbursed %s",
+		formatSignedFloat(current.TotalAwarded-previous.TotalAwarded, code),
+		formatSignedFloat(current.TotalDisbursed-previous.TotalDisbursed, code),
+	)
 }
 
 func buildTrendReportText(current, previous snapshotStats, generatedAt time.Time) string {
@@ -84,24 +174,21 @@ func buildTrendReportText(current, previous snapshotStats, generatedAt time.Time
 		"Group Scholar Pacing Trend Report",
 		fmt.Sprintf("Generated: %s", generatedAt.Format(time.RFC3339)),
 		"",
-		fmt.Sprintf("Current snapshot: %s · %d records · $%0.2f awarded · $%0.2f disbursed%s",
+		fmt.Sprintf("Current snapshot: %s · %d records · %s%s",
 			currentSnapshot.GeneratedAt,
 			currentSnapshot.RecordCount,
-			currentSnapshot.TotalAwarded,
-			currentSnapshot.TotalDisbursed,
+			formatTrendTotals(current),
 			windowNote,
 		),
-		fmt.Sprintf("Previous snapshot: %s · %d records · $%0.2f awarded · $%0.2f disbursed",
+		fmt.Sprintf("Previous snapshot: %s · %d records · %s",
 			previousSnapshot.GeneratedAt,
 			previousSnapshot.RecordCount,
-			previousSnapshot.TotalAwarded,
-			previousSnapshot.TotalDisbursed,
+			formatTrendTotals(previous),
 		),
 		"",
-		fmt.Sprintf("Delta: records %s · awarded %s · disbursed %s",
+		fmt.Sprintf("Delta: records %s · %s",
 			formatSignedInt(delta.RecordCount),
-			formatSignedFloat(delta.TotalAwarded),
-			formatSignedFloat(delta.TotalDisbursed),
+			formatTrendMoneyDelta(current, previous),
 		),
 		fmt.Sprintf("Pace mix: Ahead %s · On track %s · Behind %s",
 			formatSignedInt(delta.Ahead),
@@ -117,43 +204,115 @@ func buildTrendReportText(current, previous snapshotStats, generatedAt time.Time
 			formatSignedInt(delta.Medium),
 			formatSignedInt(delta.Low),
 		),
+		fmt.Sprintf("Risk score total: %d (%s)", currentSnapshot.TotalRisk, formatSignedInt(delta.TotalRisk)),
+		formatVelocityLine(comparableVelocity(current, previous)),
 	}
 
 	return strings.Join(lines, "\n") + "\n"
 }
 
+func buildDisbursementVelocity(current, previous snapshotStats) disbursementVelocity {
+	elapsed := current.GeneratedAt.Sub(previous.GeneratedAt).Hours() / 24
+	window := elapsed
+	floored := false
+	if window < minVelocityWindowDays {
+		window = minVelocityWindowDays
+		floored = true
+	}
+	perDay := (current.TotalDisbursed - previous.TotalDisbursed) / window
+	code, _ := snapshotCurrency(current)
+	return disbursementVelocity{
+		Currency:    code,
+		ElapsedDays: elapsed,
+		PerDay:      perDay,
+		Annualized:  perDay * 365,
+		Floored:     floored,
+		Negative:    perDay < 0,
+	}
+}
+
+func formatVelocityLine(velocity *disbursementVelocity) string {
+	if velocity == nil {
+		return "Disbursement velocity: not available (snapshots mix currencies)"
+	}
+	line := fmt.Sprintf("Disbursement velocity: %s/day · %s/yr annualized over %0.1f days",
+		formatSignedFloat(velocity.PerDay, velocity.Currency),
+		formatSignedFloat(velocity.Annualized, velocity.Currency),
+		velocity.ElapsedDays,
+	)
+	if velocity.Floored {
+		line += " (snapshots under a day apart; rate measured over 1 day)"
+	}
+	if velocity.Negative {
+		line += "\nWarning: disbursed total went down between snapshots; cumulative disbursement should not shrink, so this likely reflects a data correction."
+	}
+	return line
+}
+
 func buildTrendSnapshot(stats snapshotStats) trendSnapshot {
-	return trendSnapshot{
-		GeneratedAt:    stats.GeneratedAt.Format(time.RFC3339),
-		RecordCount:    stats.RecordCount,
-		TotalAwarded:   stats.TotalAwarded,
-		TotalDisbursed: stats.TotalDisbursed,
-		Ahead:          stats.Ahead,
-		OnTrack:        stats.OnTrack,
-		Behind:         stats.Behind,
-		Overdue:        stats.Overdue,
-		DueSoon:        stats.DueSoon,
-		High:           stats.High,
-		Medium:         stats.Medium,
-		Low:            stats.Low,
-		DueSoonWindow:  stats.DueSoonWindow,
+	snapshot := trendSnapshot{
+		GeneratedAt:   stats.GeneratedAt.Format(time.RFC3339),
+		RecordCount:   stats.RecordCount,
+		Currencies:    stats.Currencies,
+		Ahead:         stats.Ahead,
+		OnTrack:       stats.OnTrack,
+		Behind:        stats.Behind,
+		Overdue:       stats.Overdue,
+		DueSoon:       stats.DueSoon,
+		High:          stats.High,
+		Medium:        stats.Medium,
+		Low:           stats.Low,
+		TotalRisk:     stats.TotalRisk,
+		DueSoonWindow: stats.DueSoonWindow,
+	}
+	if _, ok := snapshotCurrency(stats); ok {
+		snapshot.TotalAwarded = &stats.TotalAwarded
+		snapshot.TotalDisbursed = &stats.TotalDisbursed
 	}
+	return snapshot
 }
 
 func buildTrendDelta(current, previous snapshotStats) trendDelta {
-	return trendDelta{
-		RecordCount:    current.RecordCount - previous.RecordCount,
-		TotalAwarded:   current.TotalAwarded - previous.TotalAwarded,
-		TotalDisbursed: current.TotalDisbursed - previous.TotalDisbursed,
-		Ahead:          current.Ahead - previous.Ahead,
-		OnTrack:        current.OnTrack - previous.OnTrack,
-		Behind:         current.Behind - previous.Behind,
-		Overdue:        current.Overdue - previous.Overdue,
-		DueSoon:        current.DueSoon - previous.DueSoon,
-		High:           current.High - previous.High,
-		Medium:         current.Medium - previous.Medium,
-		Low:            current.Low - previous.Low,
+	delta := trendDelta{
+		RecordCount: current.RecordCount - previous.RecordCount,
+		Ahead:       current.Ahead - previous.Ahead,
+		OnTrack:     current.OnTrack - previous.OnTrack,
+		Behind:      current.Behind - previous.Behind,
+		Overdue:     current.Overdue - previous.Overdue,
+		DueSoon:     current.DueSoon - previous.DueSoon,
+		High:        current.High - previous.High,
+		Medium:      current.Medium - previous.Medium,
+		Low:         current.Low - previous.Low,
+		TotalRisk:   current.TotalRisk - previous.TotalRisk,
 	}
+	if _, ok := trendCurrency(current, previous); ok {
+		awarded := current.TotalAwarded - previous.TotalAwarded
+		disbursed := current.TotalDisbursed - previous.TotalDisbursed
+		delta.TotalAwarded = &awarded
+		delta.TotalDisbursed = &disbursed
+	}
+	return delta
+}
+
+// parseSnapshotIDPair reads -diff-snapshots "7,12" as the baseline and the
+// snapshot compared against it.
+func parseSnapshotIDPair(raw string) (int64, int64, error) {
+	parts := splitCommaList(raw)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid snapshot ids %q (use two ids, e.g. 7,12)", raw)
+	}
+	ids := make([]int64, 0, 2)
+	for _, part := range parts {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return 0, 0, fmt.Errorf("invalid snapshot id %q", part)
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] == ids[1] {
+		return 0, 0, fmt.Errorf("snapshot ids must differ, got %d twice", ids[0])
+	}
+	return ids[0], ids[1], nil
 }
 
 func formatSignedInt(value int) string {
@@ -163,9 +322,130 @@ func formatSignedInt(value int) string {
 	return fmt.Sprintf("%d", value)
 }
 
-func formatSignedFloat(value float64) string {
+func formatSignedFloat(value float64, currency string) string {
 	if value >= 0 {
-		return fmt.Sprintf("+$%0.2f", value)
+		return "+" + formatCurrencyCents(value, currency)
+	}
+	return "-" + formatCurrencyCents(-value, currency)
+}
+
+func writeTrendSeriesReport(path, format string, series []snapshotStats, generatedAt time.Time) error {
+	format, err := normalizeReportFormat(path, format)
+	if err != nil {
+		return err
+	}
+	if format == "markdown" || format == "table" {
+		return fmt.Errorf("unsupported trend report format: %s", format)
+	}
+	if format == "json" {
+		content, err := json.MarshalIndent(buildTrendSeriesPayload(series, generatedAt), "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeReportOutput(path, content)
+	}
+	return writeReportOutput(path, []byte(buildTrendSeriesText(series, generatedAt)))
+}
+
+func buildTrendSeriesPayload(series []snapshotStats, generatedAt time.Time) trendSeriesPayload {
+	payload := trendSeriesPayload{
+		GeneratedAt: generatedAt.Format(time.RFC3339),
+		Points:      make([]trendSeriesPoint, 0, len(series)),
+	}
+	for i, stats := range series {
+		point := trendSeriesPoint{
+			Snapshot:   buildTrendSnapshot(stats),
+			Completion: snapshotCompletion(stats),
+		}
+		if i > 0 {
+			delta := buildTrendDelta(stats, series[i-1])
+			point.Delta = &delta
+		}
+		payload.Points = append(payload.Points, point)
+	}
+	if len(series) >= 2 {
+		payload.Velocity = comparableVelocity(series[len(series)-1], series[len(series)-2])
+	}
+	return payload
+}
+
+func buildTrendSeriesText(series []snapshotStats, generatedAt time.Time) string {
+	lines := []string{
+		"Group Scholar Pacing Trend Series",
+		fmt.Sprintf("Generated: %s", generatedAt.Format(time.RFC3339)),
+		fmt.Sprintf("Snapshots: %d (oldest first)", len(series)),
+	}
+	if len(series) >= 2 {
+		values := make([]float64, 0, len(series))
+		for _, stats := range series {
+			values = append(values, snapshotCompletion(stats))
+		}
+		lines = append(lines, fmt.Sprintf("Completion trend: %s (%0.1f%% → %0.1f%%)",
+			sparkline(values, activeTheme == "mono"),
+			values[0]*100,
+			values[len(values)-1]*100,
+		))
+		lines = append(lines, formatVelocityLine(comparableVelocity(series[len(series)-1], series[len(series)-2])))
+	}
+	lines = append(lines, "")
+	for i, stats := range series {
+		line := fmt.Sprintf("%s · %d records · %0.1f%% complete · Behind %d · Overdue %d · High %d",
+			stats.GeneratedAt.Format(time.RFC3339),
+			stats.RecordCount,
+			snapshotCompletion(stats)*100,
+			stats.Behind,
+			stats.Overdue,
+			stats.High,
+		)
+		if i > 0 {
+			previous := series[i-1]
+			delta := buildTrendDelta(stats, previous)
+			line += fmt.Sprintf(" (completion %s pts · behind %s · overdue %s · high %s)",
+				formatSignedPoints((snapshotCompletion(stats)-snapshotCompletion(previous))*100),
+				formatSignedInt(delta.Behind),
+				formatSignedInt(delta.Overdue),
+				formatSignedInt(delta.High),
+			)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func snapshotCompletion(stats snapshotStats) float64 {
+	if stats.TotalAwarded <= 0 {
+		return 0
+	}
+	return stats.TotalDisbursed / stats.TotalAwarded
+}
+
+func formatSignedPoints(value float64) string {
+	if value >= 0 {
+		return fmt.Sprintf("+%0.1f", value)
+	}
+	return fmt.Sprintf("%0.1f", value)
+}
+
+func sparkline(values []float64, ascii bool) string {
+	ramp := []rune("▁▂▃▄▅▆▇█")
+	if ascii {
+		ramp = []rune("_.-=+*#")
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+	out := make([]rune, 0, len(values))
+	for _, value := range values {
+		level := len(ramp) / 2
+		if high > low {
+			level = int(math.Round((value - low) / (high - low) * float64(len(ramp)-1)))
+		}
+		out = append(out, ramp[level])
 	}
-	return fmt.Sprintf("-$%0.2f", -value)
+	return string(out)
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type fileSnapshot struct {
+	GeneratedAt time.Time
+	Payload     exportSnapshotPayload
+}
+
+func loadSnapshotFiles(spec string) ([]fileSnapshot, error) {
+	parts := make([]string, 0)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if info, err := os.Stat(part); err == nil && info.IsDir() {
+			part = filepath.Join(part, "*.json")
+		}
+		parts = append(parts, part)
+	}
+	paths, err := expandDataPaths(strings.Join(parts, ","))
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]fileSnapshot, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var payload exportSnapshotPayload
+		if err := json.Unmarshal(content, &payload); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		generatedAt, err := time.Parse(time.RFC3339, payload.GeneratedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid generated_at %q", path, payload.GeneratedAt)
+		}
+		snapshots = append(snapshots, fileSnapshot{GeneratedAt: generatedAt, Payload: payload})
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].GeneratedAt.Before(snapshots[j].GeneratedAt)
+	})
+	return snapshots, nil
+}
+
+func loadTrendSnapshotSeriesFromFiles(spec string, limit int) ([]snapshotStats, error) {
+	if limit < 2 {
+		return nil, errors.New("trend window must be at least 2 snapshots")
+	}
+	snapshots, err := loadSnapshotFiles(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) < 2 {
+		return nil, errors.New("need at least two snapshots to build a trend report")
+	}
+	if len(snapshots) > limit {
+		snapshots = snapshots[len(snapshots)-limit:]
+	}
+	series := make([]snapshotStats, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		series = append(series, snapshotStatsFromExport(snapshot))
+	}
+	return series, nil
+}
+
+func snapshotStatsFromExport(snapshot fileSnapshot) snapshotStats {
+	summary := snapshot.Payload.Summary
+	totalRisk := 0
+	for _, item := range snapshot.Payload.Items {
+		totalRisk += item.RiskScore
+	}
+	currencies := make([]string, 0, len(summary.Currencies))
+	for _, total := range summary.Currencies {
+		currencies = append(currencies, total.Currency)
+	}
+	return snapshotStats{
+		GeneratedAt:    snapshot.GeneratedAt,
+		RecordCount:    summary.Count,
+		TotalAwarded:   valueOrZero(summary.TotalAwarded),
+		TotalDisbursed: valueOrZero(summary.TotalDisbursed),
+		Ahead:          summary.Ahead,
+		OnTrack:        summary.OnTrack,
+		Behind:         summary.Behind,
+		Overdue:        summary.Overdue,
+		DueSoon:        summary.DueSoon,
+		High:           summary.High,
+		Medium:         summary.Medium,
+		Low:            summary.Low,
+		TotalRisk:      totalRisk,
+		DueSoonWindow:  snapshot.Payload.CheckinWindowDays,
+		Currencies:     currencies,
+	}
+}
+
+func valueOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func loadScholarSnapshotPairFromFiles(spec string, window int) (scholarSnapshot, scholarSnapshot, error) {
+	if window < 2 {
+		return scholarSnapshot{}, scholarSnapshot{}, errors.New("trend window must be at least 2 snapshots")
+	}
+	snapshots, err := loadSnapshotFiles(spec)
+	if err != nil {
+		return scholarSnapshot{}, scholarSnapshot{}, err
+	}
+	if len(snapshots) < 2 {
+		return scholarSnapshot{}, scholarSnapshot{}, errors.New("need at least two snapshots to build a trend report")
+	}
+	if len(snapshots) > window {
+		snapshots = snapshots[len(snapshots)-window:]
+	}
+	return scholarSnapshotFromExport(snapshots[0]), scholarSnapshotFromExport(snapshots[len(snapshots)-1]), nil
+}
+
+func scholarSnapshotFromExport(snapshot fileSnapshot) scholarSnapshot {
+	rows := make([]scholarSnapshotRow, 0, len(snapshot.Payload.Items))
+	for _, item := range snapshot.Payload.Items {
+		rows = append(rows, scholarSnapshotRow{
+			Scholar:         item.Scholar,
+			Cohort:          item.Cohort,
+			Owner:           item.Owner,
+			Amount:          item.Amount,
+			DisbursedToDate: item.DisbursedToDate,
+			PaceLabel:       item.PaceLabel,
+			RiskLevel:       item.RiskLevel,
+			GapAmount:       item.GapAmount,
+			Notes:           item.Notes,
+		})
+	}
+	return scholarSnapshot{GeneratedAt: snapshot.GeneratedAt, Rows: rows}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type scholarTrendChange struct {
+	Scholar      string  `json:"scholar"`
+	Cohort       string  `json:"cohort"`
+	Owner        string  `json:"owner"`
+	Change       string  `json:"change"`
+	PreviousPace string  `json:"previous_pace,omitempty"`
+	CurrentPace  string  `json:"current_pace,omitempty"`
+	PreviousRisk string  `json:"previous_risk,omitempty"`
+	CurrentRisk  string  `json:"current_risk,omitempty"`
+	GapChange    float64 `json:"gap_change"`
+}
+
+type scholarNoteChange struct {
+	Scholar       string `json:"scholar"`
+	Cohort        string `json:"cohort"`
+	Owner         string `json:"owner"`
+	PreviousNotes string `json:"previous_notes"`
+	CurrentNotes  string `json:"current_notes"`
+}
+
+type scholarTrendPayload struct {
+	GeneratedAt string               `json:"generated_at"`
+	Current     string               `json:"current_snapshot"`
+	Previous    string               `json:"previous_snapshot"`
+	Changes     []scholarTrendChange `json:"changes"`
+	NoteChanges []scholarNoteChange  `json:"note_changes"`
+}
+
+// writeScholarTrendReport pseudonymizes names and redacts notes when anon is
+// set; notes are compared before redaction so changed notes are still listed.
+func writeScholarTrendReport(path, format string, previous, current scholarSnapshot, generatedAt time.Time, anon *anonymizer) error {
+	format, err := normalizeReportFormat(path, format)
+	if err != nil {
+		return err
+	}
+	if format == "markdown" || format == "table" {
+		return fmt.Errorf("unsupported trend report format: %s", format)
+	}
+	if anon != nil {
+		previous.Rows = anon.scholarRows(previous.Rows)
+		current.Rows = anon.scholarRows(current.Rows)
+	}
+	changes := buildScholarTrendChanges(previous.Rows, current.Rows)
+	noteChanges := buildScholarNoteChanges(previous.Rows, current.Rows)
+	if anon != nil {
+		noteChanges = redactNoteChanges(noteChanges)
+	}
+	if format == "json" {
+		payload := scholarTrendPayload{
+			GeneratedAt: generatedAt.Format(time.RFC3339),
+			Current:     current.GeneratedAt.Format(time.RFC3339),
+			Previous:    previous.GeneratedAt.Format(time.RFC3339),
+			Changes:     changes,
+			NoteChanges: noteChanges,
+		}
+		content, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeReportOutput(path, content)
+	}
+	return writeReportOutput(path, []byte(buildScholarTrendText(changes, noteChanges, previous.GeneratedAt, current.GeneratedAt, generatedAt)))
+}
+
+func buildScholarTrendChanges(previous, current []scholarSnapshotRow) []scholarTrendChange {
+	previousIndex := make(map[string]scholarSnapshotRow, len(previous))
+	for _, row := range previous {
+		previousIndex[scholarRowKey(row)] = row
+	}
+	changes := make([]scholarTrendChange, 0)
+	seen := make(map[string]struct{}, len(current))
+	for _, row := range current {
+		key := scholarRowKey(row)
+		seen[key] = struct{}{}
+		before, ok := previousIndex[key]
+		if !ok {
+			changes = append(changes, scholarTrendChange{
+				Scholar:     row.Scholar,
+				Cohort:      row.Cohort,
+				Owner:       row.Owner,
+				Change:      "new",
+				CurrentPace: row.PaceLabel,
+				CurrentRisk: row.RiskLevel,
+				GapChange:   row.GapAmount,
+			})
+			continue
+		}
+		paceWorse := paceRank(row.PaceLabel) < paceRank(before.PaceLabel)
+		riskWorse := riskRank(row.RiskLevel) < riskRank(before.RiskLevel)
+		if !paceWorse && !riskWorse {
+			continue
+		}
+		changes = append(changes, scholarTrendChange{
+			Scholar:      row.Scholar,
+			Cohort:       row.Cohort,
+			Owner:        row.Owner,
+			Change:       "worsened",
+			PreviousPace: before.PaceLabel,
+			CurrentPace:  row.PaceLabel,
+			PreviousRisk: before.RiskLevel,
+			CurrentRisk:  row.RiskLevel,
+			GapChange:    row.GapAmount - before.GapAmount,
+		})
+	}
+	for _, row := range previous {
+		if _, ok := seen[scholarRowKey(row)]; ok {
+			continue
+		}
+		changes = append(changes, scholarTrendChange{
+			Scholar:      row.Scholar,
+			Cohort:       row.Cohort,
+			Owner:        row.Owner,
+			Change:       "dropped",
+			PreviousPace: row.PaceLabel,
+			PreviousRisk: row.RiskLevel,
+			GapChange:    -row.GapAmount,
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if scholarChangeRank(changes[i].Change) != scholarChangeRank(changes[j].Change) {
+			return scholarChangeRank(changes[i].Change) < scholarChangeRank(changes[j].Change)
+		}
+		return strings.ToLower(changes[i].Scholar) < strings.ToLower(changes[j].Scholar)
+	})
+	return changes
+}
+
+func buildScholarTrendText(changes []scholarTrendChange, noteChanges []scholarNoteChange, previousAt, currentAt, generatedAt time.Time) string {
+	lines := []string{
+		"Group Scholar Per-Scholar Trend Report",
+		fmt.Sprintf("Generated: %s", generatedAt.Format(time.RFC3339)),
+		fmt.Sprintf("Comparing: %s → %s", previousAt.Format(time.RFC3339), currentAt.Format(time.RFC3339)),
+		"",
+	}
+	if len(changes) == 0 {
+		lines = append(lines, "No scholars worsened, joined, or dropped.")
+	}
+	for _, change := range changes {
+		switch change.Change {
+		case "new":
+			lines = append(lines, fmt.Sprintf("- [new] %s (%s · %s) · %s · Risk %s · Gap %s",
+				change.Scholar, change.Cohort, change.Owner, change.CurrentPace, change.CurrentRisk, formatSignedCurrency(change.GapChange)))
+		case "dropped":
+			lines = append(lines, fmt.Sprintf("- [dropped] %s (%s · %s) · was %s · Risk %s",
+				change.Scholar, change.Cohort, change.Owner, change.PreviousPace, change.PreviousRisk))
+		default:
+			lines = append(lines, fmt.Sprintf("- [worsened] %s (%s · %s) · Pace %s → %s · Risk %s → %s · Gap change %s",
+				change.Scholar, change.Cohort, change.Owner,
+				change.PreviousPace, change.CurrentPace,
+				change.PreviousRisk, change.CurrentRisk,
+				formatSignedCurrency(change.GapChange)))
+		}
+	}
+	if len(noteChanges) > 0 {
+		lines = append(lines, "", "Notes changed since the previous snapshot:")
+		for _, change := range noteChanges {
+			lines = append(lines,
+				fmt.Sprintf("- %s (%s · %s)", change.Scholar, change.Cohort, change.Owner),
+				"    was: "+noteText(change.PreviousNotes),
+				"    now: "+noteText(change.CurrentNotes),
+			)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// buildScholarNoteChanges lists scholars present in both snapshots whose notes
+// differ, ignoring surrounding whitespace.
+func buildScholarNoteChanges(previous, current []scholarSnapshotRow) []scholarNoteChange {
+	previousIndex := make(map[string]scholarSnapshotRow, len(previous))
+	for _, row := range previous {
+		previousIndex[scholarRowKey(row)] = row
+	}
+	changes := make([]scholarNoteChange, 0)
+	for _, row := range current {
+		before, ok := previousIndex[scholarRowKey(row)]
+		if !ok || strings.TrimSpace(before.Notes) == strings.TrimSpace(row.Notes) {
+			continue
+		}
+		changes = append(changes, scholarNoteChange{
+			Scholar:       row.Scholar,
+			Cohort:        row.Cohort,
+			Owner:         row.Owner,
+			PreviousNotes: strings.TrimSpace(before.Notes),
+			CurrentNotes:  strings.TrimSpace(row.Notes),
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return strings.ToLower(changes[i].Scholar) < strings.ToLower(changes[j].Scholar)
+	})
+	return changes
+}
+
+func noteText(notes string) string {
+	if notes == "" {
+		return "(none)"
+	}
+	return strings.Join(strings.Fields(notes), " ")
+}
+
+func scholarRowKey(row scholarSnapshotRow) string {
+	return strings.ToLower(strings.TrimSpace(row.Scholar)) + "|" + strings.ToLower(strings.TrimSpace(row.Cohort))
+}
+
+func scholarChangeRank(change string) int {
+	switch change {
+	case "worsened":
+		return 0
+	case "new":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func riskRank(level string) int {
+	switch level {
+	case "High":
+		return 0
+	case "Medium":
+		return 1
+	default:
+		return 2
+	}
+}
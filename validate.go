@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ValidationIssue struct {
+	Scholar string
+	Field   string
+	Problem string
+}
+
+func (i ValidationIssue) String() string {
+	scholar := strings.TrimSpace(i.Scholar)
+	if scholar == "" {
+		scholar = "(unnamed)"
+	}
+	return fmt.Sprintf("%s: %s: %s", scholar, i.Field, i.Problem)
+}
+
+func validateRecords(records []Disbursement, config pacingConfig) []ValidationIssue {
+	issues := make([]ValidationIssue, 0)
+	for _, record := range records {
+		issues = append(issues, validateRecord(record, config)...)
+	}
+	return issues
+}
+
+func validateRecord(record Disbursement, config pacingConfig) []ValidationIssue {
+	issues := make([]ValidationIssue, 0)
+	add := func(field, format string, args ...any) {
+		issues = append(issues, ValidationIssue{Scholar: record.Scholar, Field: field, Problem: fmt.Sprintf(format, args...)})
+	}
+	if record.Amount < 0 {
+		add("amount", "negative amount %0.2f", record.Amount)
+	}
+	if record.DisbursedToDate < 0 {
+		add("disbursed_to_date", "negative disbursed amount %0.2f", record.DisbursedToDate)
+	}
+	if record.Amount >= 0 && record.DisbursedToDate > record.Amount {
+		add("disbursed_to_date", "disbursed %0.2f exceeds awarded %0.2f", record.DisbursedToDate, record.Amount)
+	}
+	if record.GracePeriodDays < 0 {
+		add("grace_period_days", "negative grace period %d", record.GracePeriodDays)
+	}
+	awardDate, awardOK := validateDateField(record.AwardDate, "award_date", config, add)
+	targetDate, targetOK := validateDateField(record.TargetDate, "target_date", config, add)
+	validateDateField(record.NextCheckin, "next_checkin", config, add)
+	validateDateField(record.LastCheckin, "last_checkin", config, add)
+	if record.CheckinIntervalDays < 0 {
+		add("checkin_interval_days", "negative check-in interval %d", record.CheckinIntervalDays)
+	}
+	if awardOK && targetOK && targetDate.Before(awardDate) {
+		add("target_date", "target date %s is before award date %s", record.TargetDate, record.AwardDate)
+	}
+	for i, milestone := range record.Milestones {
+		validateDateField(milestone.Date, fmt.Sprintf("milestones[%d].date", i), config, add)
+	}
+	for i, payment := range record.PlannedPayments {
+		validateDateField(payment.Date, fmt.Sprintf("planned_payments[%d].date", i), config, add)
+		if payment.Amount < 0 {
+			add(fmt.Sprintf("planned_payments[%d].amount", i), "negative planned amount %0.2f", payment.Amount)
+		}
+	}
+	return issues
+}
+
+// recordWarnings is what exports attach to each award: the -validate issues
+// plus the gaps that validation tolerates but that leave pacing incomplete.
+func recordWarnings(record Disbursement, config pacingConfig) []string {
+	warnings := make([]string, 0)
+	if record.Amount == 0 {
+		warnings = append(warnings, "amount: zero amount; left out of totals")
+	}
+	if strings.TrimSpace(record.AwardDate) == "" {
+		warnings = append(warnings, "award_date: missing")
+	}
+	if strings.TrimSpace(record.TargetDate) == "" {
+		warnings = append(warnings, "target_date: missing")
+	}
+	for _, issue := range validateRecord(record, config) {
+		warnings = append(warnings, issue.Field+": "+issue.Problem)
+	}
+	return warnings
+}
+
+func validateDateField(value, field string, config pacingConfig, add func(field, format string, args ...any)) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	date, ok := config.ParseDate(value)
+	if !ok {
+		add(field, "unparseable date %q", value)
+		return time.Time{}, false
+	}
+	return date, true
+}
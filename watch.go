@@ -0,0 +1,190 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	defaultWatchInterval = 2 * time.Second
+	watchDebounce        = 300 * time.Millisecond
+)
+
+// dataWatch polls the -data files (size and mtime) or, in db mode, the newest
+// snapshot id over one shared connection pool. A change is reloaded only once it reads the same on two polls
+// watchDebounce apart, so bursts of writes collapse into one reload. With
+// -detect-stalled each reload also recomputes the stalled set.
+type dataWatch struct {
+	enabled     bool
+	interval    time.Duration
+	source      string
+	spec        string
+	lenient     bool
+	dbURL       string
+	timeout     time.Duration
+	stalled     bool
+	db          *sql.DB
+	fingerprint string
+	pending     string
+	checkedAt   time.Time
+}
+
+// newDataWatch validates -watch/-poll for the data source. -poll is the db
+// spelling of -watch and sets its own interval.
+func newDataWatch(source, snapshotDate string, watchInterval, pollInterval time.Duration) (dataWatch, error) {
+	interval := watchInterval
+	if pollInterval != 0 {
+		if source != "db" {
+			return dataWatch{}, errors.New("-poll needs -source db; use -watch to follow data files")
+		}
+		interval = pollInterval
+	}
+	if source == "stdin" {
+		return dataWatch{}, errors.New("-watch needs -data files or -source db, not stdin")
+	}
+	if source == "db" && strings.TrimSpace(snapshotDate) != "" {
+		return dataWatch{}, errors.New("-watch and -poll cannot follow a pinned -snapshot-date")
+	}
+	if interval <= 0 {
+		return dataWatch{}, fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+	return dataWatch{enabled: true, interval: interval, source: source}, nil
+}
+
+type watchTickMsg struct{}
+
+type watchPollMsg struct {
+	fingerprint string
+	err         error
+}
+
+type watchReloadMsg struct {
+	records     []Disbursement
+	stalled     map[string]struct{}
+	fingerprint string
+	err         error
+}
+
+func (w dataWatch) tick(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+func (w dataWatch) poll() tea.Msg {
+	fingerprint, err := w.currentFingerprint()
+	return watchPollMsg{fingerprint: fingerprint, err: err}
+}
+
+func (w dataWatch) currentFingerprint() (string, error) {
+	if w.source == "db" {
+		id, err := latestSnapshotID(w.db, w.timeout)
+		return strconv.FormatInt(id, 10), err
+	}
+	return dataFingerprint(w.spec)
+}
+
+func (w dataWatch) reload(fingerprint string) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			records []Disbursement
+			err     error
+		)
+		if w.source == "db" {
+			records, _, _, err = loadSnapshotFromDB(w.dbURL, w.timeout, time.Time{})
+		} else {
+			records, _, err = loadDataFiles(w.spec, w.lenient)
+		}
+		var stalled map[string]struct{}
+		if err == nil && w.stalled {
+			stalled, err = loadStalledAwards(w.dbURL, w.timeout)
+		}
+		return watchReloadMsg{records: records, stalled: stalled, fingerprint: fingerprint, err: err}
+	}
+}
+
+// dataFingerprint fails while any data file is missing, which is how an atomic
+// rename looks mid-write; the watcher keeps polling instead of reloading.
+func dataFingerprint(spec string) (string, error) {
+	paths, err := expandDataPaths(spec)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano()))
+	}
+	return strings.Join(parts, "|"), nil
+}
+
+func (m *model) updateWatch(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case watchTickMsg:
+		return m.watch.poll
+	case watchPollMsg:
+		m.watch.checkedAt = time.Now()
+		if msg.err != nil && m.watch.source == "db" {
+			m.statusMessage = fmt.Sprintf("Snapshot check failed: %v", msg.err)
+		}
+		if msg.err != nil || msg.fingerprint == m.watch.fingerprint {
+			m.watch.pending = ""
+			return m.watch.tick(m.watch.interval)
+		}
+		if msg.fingerprint != m.watch.pending {
+			m.watch.pending = msg.fingerprint
+			return m.watch.tick(watchDebounce)
+		}
+		m.watch.pending = ""
+		return m.watch.reload(msg.fingerprint)
+	case watchReloadMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Reload failed: %v", msg.err)
+			return m.watch.tick(m.watch.interval)
+		}
+		if m.editing {
+			return m.watch.tick(m.watch.interval)
+		}
+		if m.unsavedEdits {
+			// Leave the fingerprint alone so the change is picked up after a save.
+			m.statusMessage = "Data changed; reload waits until check-in edits are saved (w)."
+			return m.watch.tick(m.watch.interval)
+		}
+		m.watch.fingerprint = msg.fingerprint
+		if m.watch.stalled {
+			m.stalled = msg.stalled
+		}
+		m.applyReloadedRecords(msg.records)
+		m.statusMessage = fmt.Sprintf("Reloaded %d records at %s", len(m.records), m.updatedAt.Format("15:04:05"))
+		return m.watch.tick(m.watch.interval)
+	}
+	return nil
+}
+
+func (m *model) applyReloadedRecords(records []Disbursement) {
+	selected := ""
+	if item, ok := m.list.SelectedItem().(awardItem); ok {
+		selected = recordKey(item.data)
+	}
+	records, duplicates := dedupeRecords(records)
+	m.records = applyRecordFilters(records, m.filters)
+	m.saveBlocked = saveBlockedReason(len(duplicates))
+	m.updatedAt = m.now()
+	selectRecord := -1
+	for i, record := range m.records {
+		if recordKey(record) == selected {
+			selectRecord = i
+			break
+		}
+	}
+	m.rebuildItems(selectRecord)
+	m.refreshPanels()
+}
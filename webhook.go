@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const webhookAttempts = 3
+
+var webhookBackoff = time.Second
+
+type webhookOptions struct {
+	ContentType   string
+	Authorization string
+}
+
+func postSnapshotWebhook(url string, items []awardItem, metrics summaryMetrics, generatedAt time.Time, checkinWindow int, options webhookOptions) (string, error) {
+	body, err := buildSnapshotJSON(items, metrics, generatedAt, checkinWindow)
+	if err != nil {
+		return "", err
+	}
+	contentType := strings.TrimSpace(options.ContentType)
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	var lastErr error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(webhookBackoff * time.Duration(1<<(attempt-2)))
+		}
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", contentType)
+		if strings.TrimSpace(options.Authorization) != "" {
+			req.Header.Set("Authorization", strings.TrimSpace(options.Authorization))
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("webhook returned %s", resp.Status)
+			continue
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return resp.Status, fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+		}
+		return resp.Status, nil
+	}
+	return "", fmt.Errorf("after %d attempts: %w", webhookAttempts, lastErr)
+}